@@ -0,0 +1,227 @@
+package wslogger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncPolicy define o que acontece quando a fila do modo assíncrono está
+// cheia.
+type AsyncPolicy int
+
+const (
+	// AsyncBlock bloqueia a chamada de log até haver espaço na fila.
+	AsyncBlock AsyncPolicy = iota
+	// AsyncDropNewest descarta o registro que está sendo enfileirado.
+	AsyncDropNewest
+	// AsyncDropOldest descarta o registro mais antigo da fila para abrir
+	// espaço ao novo.
+	AsyncDropOldest
+)
+
+// WithAsync ativa o modo assíncrono: as linhas já formatadas são
+// enfileiradas (até bufferSize) e escritas por uma goroutine dedicada, de
+// modo que writers lentos não bloqueiam quem loga. Use Flush para esperar
+// a fila esvaziar e Close no encerramento. Loggers derivados via With
+// compartilham a mesma fila.
+func WithAsync(bufferSize int, policy AsyncPolicy) Option {
+	return func(l *Logger) {
+		if bufferSize > 0 {
+			l.asyncSize = bufferSize
+			l.asyncPolicy = policy
+		}
+	}
+}
+
+// asyncWriter é a fila do modo assíncrono.
+type asyncWriter struct {
+	writeMu *sync.Mutex
+	policy  AsyncPolicy
+	ch      chan asyncLine
+
+	// closeMu impede que Close feche o canal durante um envio.
+	closeMu sync.RWMutex
+	closed  bool
+	exited  chan struct{}
+
+	// pending conta registros enfileirados e ainda não escritos nem
+	// descartados; Flush espera que chegue a zero.
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending int
+
+	dropped atomic.Uint64
+}
+
+// asyncLine é uma linha formatada e o writer (do Logger ou de um sink) a
+// que se destina.
+type asyncLine struct {
+	w    io.Writer
+	line []byte
+}
+
+func newAsyncWriter(writeMu *sync.Mutex, size int, policy AsyncPolicy) *asyncWriter {
+	a := &asyncWriter{
+		writeMu: writeMu,
+		policy:  policy,
+		ch:      make(chan asyncLine, size),
+		exited:  make(chan struct{}),
+	}
+	a.cond = sync.NewCond(&a.mu)
+	go a.run()
+	return a
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.exited)
+	for item := range a.ch {
+		a.writeMu.Lock()
+		_, _ = item.w.Write(item.line)
+		a.writeMu.Unlock()
+		a.done()
+	}
+}
+
+// enqueue aplica a política da fila. Retorna false se a fila já foi
+// fechada, caso em que o chamador escreve de forma síncrona.
+func (a *asyncWriter) enqueue(w io.Writer, b []byte) bool {
+	a.closeMu.RLock()
+	defer a.closeMu.RUnlock()
+	if a.closed {
+		return false
+	}
+	a.mu.Lock()
+	a.pending++
+	a.mu.Unlock()
+
+	line := asyncLine{w, b}
+	switch a.policy {
+	case AsyncDropNewest:
+		select {
+		case a.ch <- line:
+		default:
+			a.drop()
+		}
+	case AsyncDropOldest:
+		for {
+			select {
+			case a.ch <- line:
+				return true
+			default:
+			}
+			select {
+			case <-a.ch:
+				a.drop()
+			default:
+			}
+		}
+	default:
+		a.ch <- line
+	}
+	return true
+}
+
+func (a *asyncWriter) drop() {
+	a.dropped.Add(1)
+	a.done()
+}
+
+func (a *asyncWriter) done() {
+	a.mu.Lock()
+	a.pending--
+	if a.pending == 0 {
+		a.cond.Broadcast()
+	}
+	a.mu.Unlock()
+}
+
+// wait bloqueia até que todos os registros enfileirados tenham sido
+// escritos ou descartados.
+func (a *asyncWriter) wait() {
+	a.mu.Lock()
+	for a.pending > 0 {
+		a.cond.Wait()
+	}
+	a.mu.Unlock()
+}
+
+// close para de aceitar registros e espera a goroutine escrever o que
+// restou na fila, ou ctx expirar.
+func (a *asyncWriter) close(ctx context.Context) error {
+	a.closeMu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.ch)
+	}
+	a.closeMu.Unlock()
+	select {
+	case <-a.exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped retorna quantos registros foram descartados pela política do
+// modo assíncrono desde a criação do logger.
+func (l *Logger) Dropped() uint64 {
+	if l.async == nil {
+		return 0
+	}
+	return l.async.dropped.Load()
+}
+
+// Flush escreve os resumos pendentes do sampler, espera a fila assíncrona
+// esvaziar e descarrega o writer (ver Sync). Sem WithAsync, apenas
+// descarrega o writer.
+func (l *Logger) Flush() error {
+	if l.summary != nil {
+		l.summary.flush()
+	}
+	if l.async != nil {
+		l.async.wait()
+	}
+	return l.syncOutputs()
+}
+
+// Close encerra o logger: os resumos pendentes do sampler são escritos, a
+// fila assíncrona para de aceitar registros, é drenada e o writer é
+// descarregado. Se ctx expirar antes da drenagem
+// terminar, retorna ctx.Err(). Registros emitidos após Close são escritos
+// de forma síncrona.
+func (l *Logger) Close(ctx context.Context) error {
+	if l.summary != nil {
+		l.summary.stop()
+		l.summary.flush()
+	}
+	if l.async != nil {
+		if err := l.async.close(ctx); err != nil {
+			return err
+		}
+	}
+	return l.syncOutputs()
+}
+
+// syncOutputs descarrega o writer do Logger ou, com WithSinks, o de cada
+// sink; sinks OpenTelemetry chamam ForceFlush do provider, quando houver.
+func (l *Logger) syncOutputs() error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	if len(l.sinks) == 0 {
+		return syncWriter(l.writer)
+	}
+	var errs []error
+	for _, s := range l.sinks {
+		if s.LoggerProvider != nil {
+			if f, ok := s.LoggerProvider.(interface{ ForceFlush(context.Context) error }); ok {
+				errs = append(errs, f.ForceFlush(context.Background()))
+			}
+			continue
+		}
+		errs = append(errs, syncWriter(s.Writer))
+	}
+	return errors.Join(errs...)
+}
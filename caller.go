@@ -0,0 +1,225 @@
+package wslogger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Flags para formato do caller
+const (
+	CallerFlagFull   uint8 = iota // função,arquivo:linha
+	CallerFlagFunc                // função
+	CallerFlagFcLine              // função:linha
+	CallerFlagPkg                 // pacote
+	CallerFlagPkgFnl              // pacote,arquivo:linha
+	CallerFlagFnlFcn              // arquivo:linha,função
+	CallerFlagFnLine              // arquivo:linha
+	CallerFlagFcName              // nome da função
+	CallerFlagFpLine              // caminho/arquivo:linha
+)
+
+// CallerFormatFn formata o frame do callsite para o placeholder {caller}
+// e o campo "caller" do JSON.
+type CallerFormatFn func(*runtime.Frame) string
+
+// WithCallerFlag seleciona um dos formatos CallerFlag* para o caller.
+// Nos formatos, "função" é o nome qualificado pelo pacote (ex.:
+// main.handler), "nome da função" é o nome simples (handler) e "arquivo"
+// é o basename do arquivo.
+func WithCallerFlag(flag uint8) Option {
+	return func(l *Logger) { l.callerFormat = callerFlagFormatter(flag) }
+}
+
+// WithCallerFormatter define uma função própria para formatar o caller.
+// Entre WithCallerFlag e WithCallerFormatter, prevalece a última aplicada.
+func WithCallerFormatter(fn CallerFormatFn) Option {
+	return func(l *Logger) { l.callerFormat = fn }
+}
+
+// WithCallerSkip descarta n frames adicionais ao determinar o caller, para
+// pacotes que encapsulam o Logger em funções próprias.
+func WithCallerSkip(n int) Option {
+	return func(l *Logger) {
+		if n >= 0 {
+			l.callerSkip = n
+		}
+	}
+}
+
+// callerString é um caller já formatado, anexado como goroutine_caller
+// por WrapGoroutine e usado sem normalização adicional.
+type callerString string
+
+// cachedFrame guarda o frame resolvido de um PC e os formatos padrão, para
+// que callsites repetidos custem apenas uma consulta ao cache.
+type cachedFrame struct {
+	frame runtime.Frame
+	text  string // basename:função:linha
+	json  string // basename:linha
+}
+
+// frameCache mapeia PC -> *cachedFrame. Os PCs de um binário são finitos,
+// então o cache é limitado ao número de callsites de log.
+var frameCache sync.Map
+
+// callerFrame retorna o frame skip níveis acima de quem chamou
+// callerFrame (skip=0 é o próprio chamador), como runtime.Caller.
+// A resolução usa apenas runtime.Frames, funcionando em binários sem o
+// código-fonte, e é memorizada por PC.
+func callerFrame(skip int) runtime.Frame {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return runtime.Frame{}
+	}
+	return lookupFrame(pcs[0]).frame
+}
+
+func lookupFrame(pc uintptr) *cachedFrame {
+	if cf, ok := frameCache.Load(pc); ok {
+		return cf.(*cachedFrame)
+	}
+	fr, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	// guarda o PC original como chave de lookup em formatCaller
+	fr.PC = pc
+	cf := &cachedFrame{
+		frame: fr,
+		text:  formatFrame(fr),
+		json:  filepath.Base(fr.File) + ":" + strconv.Itoa(fr.Line),
+	}
+	actual, _ := frameCache.LoadOrStore(pc, cf)
+	return actual.(*cachedFrame)
+}
+
+// formatCaller aplica o formato configurado. Sem configuração, o modo
+// texto usa basename:função:linha e o JSON basename:linha.
+func (l *Logger) formatCaller(fr runtime.Frame, jsonMode bool) string {
+	if fr.PC == 0 {
+		return "unknown"
+	}
+	if l.callerFormat != nil {
+		return l.callerFormat(&fr)
+	}
+	cf := lookupFrame(fr.PC)
+	if jsonMode {
+		return cf.json
+	}
+	return cf.text
+}
+
+// callerFor retorna o caller do Record no formato do encoder (JSON ou
+// texto), para que sinks com encoders diferentes saiam como nos modos
+// equivalentes. Records sem frame (LogRecord, goroutine_caller) ou cujo
+// Caller foi alterado por um hook usam Caller como está.
+func (l *Logger) callerFor(r *Record, jsonMode bool) string {
+	if r.frame.PC == 0 || r.Caller != r.caller {
+		return r.Caller
+	}
+	return l.formatCaller(r.frame, jsonMode)
+}
+
+// resolveGoroutineCaller retorna o goroutine_caller normalizado, quando
+// presente nos extras, para ser usado como caller principal do registro.
+func resolveGoroutineCaller(extras []KeyValuePair, fr runtime.Frame) (string, bool) {
+	var v any
+	found := false
+	for _, kv := range extras {
+		if kv.key == "goroutine_caller" {
+			v, found = kv.value, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	if cs, ok := v.(callerString); ok {
+		return string(cs), true
+	}
+	return normalizeGoroutineCaller(normalizeText(fmt.Sprint(v)), fr), true
+}
+
+// normalizeGoroutineCaller reduz um goroutine_caller informado
+// manualmente ao basename do arquivo: "path/file.go:line" vira
+// "file.go:line", e "path/file.go:func" é completado com a linha do
+// callsite atual.
+func normalizeGoroutineCaller(v string, fr runtime.Frame) string {
+	i := strings.LastIndex(v, ":")
+	if i < 0 {
+		return filepath.Base(v)
+	}
+	path, last := v[:i], v[i+1:]
+	if _, err := strconv.Atoi(last); err == nil {
+		return filepath.Base(path) + ":" + last
+	}
+	if fr.Line > 0 {
+		return filepath.Base(path) + ":" + last + ":" + strconv.Itoa(fr.Line)
+	}
+	return filepath.Base(path) + ":" + last
+}
+
+// formatFrame formata um frame como basename:função:linha.
+func formatFrame(fr runtime.Frame) string {
+	// extrai nome simples da função (por último segmento após '.')
+	parts := strings.Split(fr.Function, ".")
+	fn := parts[len(parts)-1]
+	return fmt.Sprintf("%s:%s:%d", filepath.Base(fr.File), fn, fr.Line)
+}
+
+func callerFlagFormatter(flag uint8) CallerFormatFn {
+	return func(fr *runtime.Frame) string {
+		file := filepath.Base(fr.File)
+		line := strconv.Itoa(fr.Line)
+		switch flag {
+		case CallerFlagFull:
+			return frameFunc(fr) + "," + file + ":" + line
+		case CallerFlagFunc:
+			return frameFunc(fr)
+		case CallerFlagFcLine:
+			return frameFunc(fr) + ":" + line
+		case CallerFlagPkg:
+			return framePkg(fr)
+		case CallerFlagPkgFnl:
+			return framePkg(fr) + "," + file + ":" + line
+		case CallerFlagFnlFcn:
+			return file + ":" + line + "," + frameFunc(fr)
+		case CallerFlagFnLine:
+			return file + ":" + line
+		case CallerFlagFcName:
+			return frameFuncName(fr)
+		case CallerFlagFpLine:
+			return fr.File + ":" + line
+		default:
+			return formatFrame(*fr)
+		}
+	}
+}
+
+// frameFunc retorna a função qualificada pelo nome curto do pacote, ex.:
+// github.com/a/b/pkg.(*T).Method -> pkg.(*T).Method.
+func frameFunc(fr *runtime.Frame) string {
+	fn := fr.Function
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		fn = fn[i+1:]
+	}
+	return fn
+}
+
+// framePkg retorna o nome curto do pacote da função do frame.
+func framePkg(fr *runtime.Frame) string {
+	fn := frameFunc(fr)
+	if i := strings.Index(fn, "."); i >= 0 {
+		return fn[:i]
+	}
+	return fn
+}
+
+// frameFuncName retorna apenas o nome da função, sem pacote nem receiver.
+func frameFuncName(fr *runtime.Frame) string {
+	fn := frameFunc(fr)
+	if i := strings.LastIndex(fn, "."); i >= 0 {
+		return fn[i+1:]
+	}
+	return fn
+}
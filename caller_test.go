@@ -0,0 +1,116 @@
+package wslogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLogger_CallerFlags(t *testing.T) {
+	// o nome do pacote no runtime é o último segmento do import path
+	fn := "go-wslogger.TestLogger_CallerFlags"
+
+	cases := []struct {
+		flag uint8
+		want func(file, line string) string
+	}{
+		{CallerFlagFull, func(_, ln string) string { return fn + ",caller_test.go:" + ln }},
+		{CallerFlagFunc, func(_, _ string) string { return fn }},
+		{CallerFlagFcLine, func(_, ln string) string { return fn + ":" + ln }},
+		{CallerFlagPkg, func(_, _ string) string { return "go-wslogger" }},
+		{CallerFlagPkgFnl, func(_, ln string) string { return "go-wslogger,caller_test.go:" + ln }},
+		{CallerFlagFnlFcn, func(_, ln string) string { return "caller_test.go:" + ln + "," + fn }},
+		{CallerFlagFnLine, func(_, ln string) string { return "caller_test.go:" + ln }},
+		{CallerFlagFcName, func(_, _ string) string { return "TestLogger_CallerFlags" }},
+		{CallerFlagFpLine, func(file, ln string) string { return file + ":" + ln }},
+	}
+	for _, tc := range cases {
+		var buf strings.Builder
+		l := NewLogger(WithWriter(&buf), WithFormat("<{caller}>"), WithCallerFlag(tc.flag))
+		l.Info("msg")
+		_, file, line, _ := runtime.Caller(0)
+		want := "<" + tc.want(file, fmt.Sprint(line-1)) + ">"
+		if got := strings.TrimSpace(buf.String()); got != want {
+			t.Errorf("flag %d: obteve %q, esperado %q", tc.flag, got, want)
+		}
+	}
+}
+
+func TestLogger_CallerFormatterJSON(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(
+		WithWriter(&buf),
+		WithJSON(true),
+		WithCallerFormatter(func(fr *runtime.Frame) string {
+			return "custom@" + fmt.Sprint(fr.Line)
+		}),
+	)
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("custom caller")
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+	}
+	if want := fmt.Sprintf("custom@%d", line+1); record["caller"] != want {
+		t.Errorf("caller JSON = %v, esperado %q", record["caller"], want)
+	}
+}
+
+// logVia simula um pacote que encapsula o Logger.
+func logVia(l *Logger, msg string) {
+	l.Info(msg)
+}
+
+func TestLogger_CallerSkip(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithFormat("{caller}"), WithCallerFlag(CallerFlagFcName))
+
+	logVia(l, "sem skip")
+	if got := strings.TrimSpace(buf.String()); got != "logVia" {
+		t.Errorf("sem skip o caller deveria ser o wrapper, obteve %q", got)
+	}
+	buf.Reset()
+
+	l = NewLogger(WithWriter(&buf), WithFormat("{caller}"),
+		WithCallerFlag(CallerFlagFcName), WithCallerSkip(1))
+	logVia(l, "com skip")
+	if got := strings.TrimSpace(buf.String()); got != "TestLogger_CallerSkip" {
+		t.Errorf("com skip o caller deveria ser o teste, obteve %q", got)
+	}
+
+	// O skip é herdado por loggers derivados e vale para os métodos *f.
+	buf.Reset()
+	l.With("k", "v").Infof("%s", "f")
+	if got := strings.TrimSpace(buf.String()); strings.HasPrefix(got, "TestLogger") {
+		t.Errorf("skip deveria pular o frame do teste no logger derivado, obteve %q", got)
+	}
+}
+
+func TestLogger_CallerFrameCache(t *testing.T) {
+	l := NewLogger(WithWriter(io.Discard))
+	l.Info("cached")
+	found := false
+	frameCache.Range(func(_, v any) bool {
+		cf := v.(*cachedFrame)
+		if cf.frame.Function == "github.com/thiagozs/go-wslogger.TestLogger_CallerFrameCache" {
+			found = true
+			return false
+		}
+		return true
+	})
+	if !found {
+		t.Error("callsite deveria estar no cache de frames após o primeiro log")
+	}
+}
+
+func BenchmarkLogger_Caller(b *testing.B) {
+	l := NewLogger(WithWriter(io.Discard))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("bench")
+	}
+}
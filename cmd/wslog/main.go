@@ -0,0 +1,461 @@
+// Command wslog renderiza logs JSON do wslogger (WithJSON) no formato
+// texto, com as mesmas cores e template do modo texto, e filtra registros
+// por nível, app, trace_id, intervalo de tempo e extras.
+//
+// Uso:
+//
+//	wslog [flags] [arquivo ...]
+//
+// Sem arquivos (ou com "-") lê a entrada padrão. Linhas que não são JSON
+// são repassadas sem alteração.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	logger "github.com/thiagozs/go-wslogger"
+)
+
+const defaultFormat = "[{time}] [{app_name}] [{caller}] [{level}] {message} {extra}"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// whereFlag acumula filtros -where chave=valor.
+type whereFlag []string
+
+func (w *whereFlag) String() string { return strings.Join(*w, ",") }
+
+func (w *whereFlag) Set(v string) error {
+	if !strings.Contains(v, "=") {
+		return fmt.Errorf("esperado chave=valor, obtido %q", v)
+	}
+	*w = append(*w, v)
+	return nil
+}
+
+// filters são os critérios de seleção de registros.
+type filters struct {
+	app     string
+	traceID string
+	since   time.Time
+	until   time.Time
+	where   map[string]string
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("wslog", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var (
+		where      whereFlag
+		f          filters
+		level      = fs.String("level", "", "nível mínimo (TRACE, DEBUG, INFO, WARN, ERROR, PANIC, FATAL)")
+		format     = fs.String("format", defaultFormat, "template de saída, como em WithFormat")
+		timeFormat = fs.String("time-format", logger.TimeFormatDefault, "layout de hora da saída")
+		color      = fs.Bool("color", true, "colorir a saída")
+		utc        = fs.Bool("utc", false, "exibir horários em UTC")
+		inTZ       = fs.String("in-tz", "Local", "fuso dos horários sem fuso na entrada e em -since/-until (Local, UTC ou nome IANA)")
+		since      = fs.String("since", "", "apenas registros a partir de (RFC3339, \"2006-01-02 15:04:05\" ou duração, ex.: 15m)")
+		until      = fs.String("until", "", "apenas registros até (mesmos formatos de -since)")
+	)
+	fs.StringVar(&f.app, "app", "", "apenas registros deste app_name")
+	fs.StringVar(&f.traceID, "trace", "", "apenas registros deste trace_id")
+	fs.Var(&where, "where", "apenas registros com o extra chave=valor (repetível)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	loc, err := time.LoadLocation(*inTZ)
+	if err != nil {
+		fmt.Fprintf(stderr, "wslog: -in-tz: fuso desconhecido %q\n", *inTZ)
+		return 2
+	}
+	now := time.Now()
+	if f.since, err = parseTimeArg(*since, now, loc); err != nil {
+		fmt.Fprintf(stderr, "wslog: -since: %v\n", err)
+		return 2
+	}
+	if f.until, err = parseTimeArg(*until, now, loc); err != nil {
+		fmt.Fprintf(stderr, "wslog: -until: %v\n", err)
+		return 2
+	}
+	f.where = make(map[string]string, len(where))
+	for _, w := range where {
+		k, v, _ := strings.Cut(w, "=")
+		f.where[k] = v
+	}
+
+	out := logger.NewLogger(
+		logger.WithWriter(stdout),
+		logger.WithFormat(*format),
+		logger.WithColor(*color),
+		logger.WithTimeFormat(*timeFormat),
+		logger.WithUTC(*utc),
+		logger.WithLevel(logger.Level(math.MinInt32)),
+	)
+	p := newPrinter(out, f, loc)
+	if *level != "" {
+		lv, ok := p.levels[strings.ToUpper(*level)]
+		if !ok {
+			fmt.Fprintf(stderr, "wslog: nível desconhecido %q\n", *level)
+			return 2
+		}
+		out.SetLevel(lv)
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	code := 0
+	for _, name := range files {
+		if err := p.printFile(name, stdin, stdout); err != nil {
+			fmt.Fprintf(stderr, "wslog: %v\n", err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// printer converte linhas JSON em Records e as emite pelo Logger de
+// saída.
+type printer struct {
+	out    *logger.Logger
+	f      filters
+	loc    *time.Location // fuso dos horários de entrada sem fuso
+	levels map[string]logger.Level
+	next   logger.Level // próxima severidade livre para níveis desconhecidos
+}
+
+func newPrinter(out *logger.Logger, f filters, loc *time.Location) *printer {
+	p := &printer{out: out, f: f, loc: loc, levels: make(map[string]logger.Level), next: 1000}
+	for _, lv := range []logger.Level{
+		logger.LevelTrace, logger.LevelDebug, logger.LevelInfo, logger.LevelWarn,
+		logger.LevelError, logger.LevelPanic, logger.LevelFatal,
+	} {
+		p.levels[lv.String()] = lv
+	}
+	return p
+}
+
+// level resolve o nome de um nível. Nomes desconhecidos (níveis
+// customizados) são registrados sem cor numa severidade acima de FATAL,
+// de modo que nunca são ocultados por -level.
+func (p *printer) level(name string) logger.Level {
+	if lv, ok := p.levels[name]; ok {
+		return lv
+	}
+	lv := p.next
+	p.next++
+	p.levels[name] = lv
+	p.out.RegisterLevel(lv, name, "")
+	return lv
+}
+
+func (p *printer) printFile(name string, stdin io.Reader, stdout io.Writer) error {
+	in := stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if !p.printLine(line) {
+			_, _ = stdout.Write(append(line, '\n'))
+		}
+	}
+	return sc.Err()
+}
+
+// printLine emite a linha se for um registro JSON que passa nos filtros.
+// Retorna false quando a linha não é JSON e deve ser repassada.
+func (p *printer) printLine(line []byte) bool {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return false
+	}
+	rec, app, err := p.parse(trimmed)
+	if err != nil {
+		return false
+	}
+	if !p.match(rec, app) {
+		return true
+	}
+	if app == "" {
+		app = "-"
+	}
+	p.out.SetAppName(app)
+	p.out.LogRecord(context.Background(), rec)
+	return true
+}
+
+func (p *printer) match(rec logger.Record, app string) bool {
+	f := p.f
+	if f.app != "" && app != f.app {
+		return false
+	}
+	if f.traceID != "" && rec.TraceID != f.traceID {
+		return false
+	}
+	if !f.since.IsZero() && (rec.Time.IsZero() || rec.Time.Before(f.since)) {
+		return false
+	}
+	if !f.until.IsZero() && (rec.Time.IsZero() || rec.Time.After(f.until)) {
+		return false
+	}
+	for k, want := range f.where {
+		v, ok := rec.Field(k)
+		if !ok || valueString(v) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// parse decodifica um registro preservando a ordem dos extras. Chaves
+// desconhecidas no nível superior (ex.: atributos adicionados por
+// versões futuras) viram campos.
+func (p *printer) parse(data []byte) (logger.Record, string, error) {
+	var rec logger.Record
+	var app string
+	fields, err := decodeObject(data)
+	if err != nil {
+		return rec, "", err
+	}
+	rec.Caller = "unknown"
+	for _, fd := range fields {
+		switch fd.key {
+		case "time":
+			// exibido no fuso local (ou em UTC com -utc), qualquer que
+			// seja o layout da entrada
+			rec.Time = parseRecordTime(fd.raw, p.loc).Local()
+		case "level":
+			rec.Level = p.level(stringValue(fd.raw))
+		case "app_name":
+			app = stringValue(fd.raw)
+		case "caller":
+			if c := stringValue(fd.raw); c != "" {
+				rec.Caller = c
+			}
+		case "message":
+			rec.Message = stringValue(fd.raw)
+		case "trace_id":
+			rec.TraceID = stringValue(fd.raw)
+		case "span_id":
+			rec.SpanID = stringValue(fd.raw)
+		case "trace_flags":
+			rec.TraceFlags = stringValue(fd.raw)
+		case "trace_state":
+			rec.TraceState = stringValue(fd.raw)
+		case "parent_span_id":
+			rec.ParentSpanID = stringValue(fd.raw)
+		case "parent_remote":
+			rec.RemoteParent = string(fd.raw) == "true"
+		case "trace_sampled", "traceparent":
+			// derivados de trace_flags e dos IDs
+		case "extra":
+			extras, err := decodeObject(fd.raw)
+			if err != nil {
+				return rec, "", err
+			}
+			for _, x := range extras {
+				addField(&rec, x.key, x.raw)
+			}
+		default:
+			addField(&rec, fd.key, fd.raw)
+		}
+	}
+	return rec, app, nil
+}
+
+type rawField struct {
+	key string
+	raw json.RawMessage
+}
+
+// decodeObject lê um objeto JSON mantendo a ordem das chaves.
+func decodeObject(data []byte) ([]rawField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("não é um objeto JSON")
+	}
+	var out []rawField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		out = append(out, rawField{key, raw})
+	}
+	return out, nil
+}
+
+// addField converte um valor JSON no campo equivalente ao modo texto:
+// erros estruturados voltam a key=msg key.type=T key.chain=... e outros
+// objetos são achatados com chaves pontuadas.
+func addField(rec *logger.Record, key string, raw json.RawMessage) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return
+	}
+	switch raw[0] {
+	case '{':
+		obj, err := decodeObject(raw)
+		if err != nil {
+			rec.AddField(key, string(raw))
+			return
+		}
+		if addErrorField(rec, key, obj) {
+			return
+		}
+		for _, x := range obj {
+			addField(rec, key+"."+x.key, x.raw)
+		}
+	case '[':
+		var v []any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			rec.AddField(key, string(raw))
+			return
+		}
+		rec.AddField(key, v)
+	case '"':
+		rec.AddField(key, stringValue(raw))
+	case 't', 'f':
+		rec.AddField(key, raw[0] == 't')
+	case 'n':
+		rec.AddField(key, nil)
+	default:
+		rec.AddField(key, json.Number(raw))
+	}
+}
+
+// addErrorField reconhece o objeto {"message","type","chain","stack"}
+// produzido para erros.
+func addErrorField(rec *logger.Record, key string, obj []rawField) bool {
+	var info struct {
+		Message *string `json:"message"`
+		Type    *string `json:"type"`
+		Chain   []struct {
+			Type string `json:"type"`
+		} `json:"chain"`
+		Stack []string `json:"stack"`
+	}
+	for _, x := range obj {
+		switch x.key {
+		case "message", "type", "chain", "stack":
+		default:
+			return false
+		}
+	}
+	raw, _ := json.Marshal(objectOf(obj))
+	if json.Unmarshal(raw, &info) != nil || info.Message == nil || info.Type == nil {
+		return false
+	}
+	rec.AddField(key, *info.Message)
+	rec.AddField(key+".type", *info.Type)
+	if len(info.Chain) > 0 {
+		types := make([]string, len(info.Chain))
+		for i, c := range info.Chain {
+			types[i] = c.Type
+		}
+		rec.AddField(key+".chain", strings.Join(types, ">"))
+	}
+	if len(info.Stack) > 0 {
+		locs := make([]string, len(info.Stack))
+		for i, s := range info.Stack {
+			// "pkg.func /path/file.go:42" -> "file.go:42"
+			locs[i] = filepath.Base(s[strings.LastIndexByte(s, ' ')+1:])
+		}
+		rec.AddField(key+".stack", strings.Join(locs, ">"))
+	}
+	return true
+}
+
+func objectOf(fields []rawField) map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		m[f.key] = f.raw
+	}
+	return m
+}
+
+func stringValue(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// valueString é a forma usada por -where para comparar valores.
+func valueString(v any) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprint(v)
+}
+
+// parseRecordTime aceita os formatos de WithTimeFormat: RFC3339(Nano), o
+// layout padrão (sem fuso, lido em loc) e os números de
+// TimeFormatUnix/UnixMilli. Retorna o tempo zero se não reconhecer o
+// valor.
+func parseRecordTime(raw json.RawMessage, loc *time.Location) time.Time {
+	if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+		if n > 1e12 {
+			return time.UnixMilli(n)
+		}
+		return time.Unix(n, 0)
+	}
+	t, _ := parseTime(stringValue(raw), loc)
+	return t
+}
+
+// parseTime lê RFC3339(Nano) ou o layout padrão, que não tem fuso e é
+// interpretado em loc (-in-tz).
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(logger.TimeFormatDefault, s, loc)
+}
+
+// parseTimeArg interpreta -since/-until: um horário absoluto ou uma
+// duração relativa a now.
+func parseTimeArg(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := parseTime(s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("horário inválido %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sample = `{"time":"2025-08-18T10:00:00Z","level":"DEBUG","app_name":"api","caller":"a.go:1","message":"cache miss","extra":{"key":"u:1"}}
+{"time":"2025-08-18T10:05:00Z","level":"WARN","app_name":"api","caller":"b.go:2","message":"slow query","trace_id":"t1","span_id":"s1","extra":{"ms":950,"table":"orders"}}
+not json
+{"time":"2025-08-18T10:10:00Z","level":"ERROR","app_name":"worker","caller":"c.go:3","message":"job failed","extra":{"error":{"message":"disk full","type":"*errors.errorString"}}}
+`
+
+func runWslog(t *testing.T, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-color=false", "-utc"}, args...)
+	if code := run(args, strings.NewReader(sample), &stdout, &stderr); code != 0 {
+		t.Fatalf("run(%v) = %d: %s", args, code, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestRun_RendersTemplate(t *testing.T) {
+	out := runWslog(t)
+	want := []string{
+		"[2025-08-18 10:00:00] [api] [a.go:1] [DEBUG] cache miss key=u:1",
+		"[2025-08-18 10:05:00] [api] [b.go:2] [WARN] slow query ms=950 table=orders trace_id=t1 span_id=s1",
+		"not json",
+		`[2025-08-18 10:10:00] [worker] [c.go:3] [ERROR] job failed error="disk full" error.type=*errors.errorString`,
+	}
+	if got := strings.Split(strings.TrimSpace(out), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("saída:\n%s\nesperado:\n%s", out, strings.Join(want, "\n"))
+	}
+}
+
+func TestRun_Filters(t *testing.T) {
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-level", "warn"}, []string{"slow query", "job failed"}},
+		{[]string{"-app", "worker"}, []string{"job failed"}},
+		{[]string{"-trace", "t1"}, []string{"slow query"}},
+		{[]string{"-since", "2025-08-18T10:01:00Z", "-until", "2025-08-18T10:06:00Z"}, []string{"slow query"}},
+		{[]string{"-where", "table=orders", "-where", "ms=950"}, []string{"slow query"}},
+		{[]string{"-where", "error.type=*errors.errorString"}, []string{"job failed"}},
+	}
+	for _, c := range cases {
+		out := runWslog(t, c.args...)
+		for _, msg := range []string{"cache miss", "slow query", "job failed"} {
+			wanted := strings.Contains(strings.Join(c.want, "|"), msg)
+			if strings.Contains(out, msg) != wanted {
+				t.Errorf("%v: presença de %q deveria ser %v:\n%s", c.args, msg, wanted, out)
+			}
+		}
+	}
+}
+
+func TestRun_Colors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	in := `{"time":"2025-08-18T10:00:00Z","level":"ERROR","message":"boom"}` + "\n"
+	if code := run([]string{"-format", "{level} {message}"}, strings.NewReader(in), &stdout, &stderr); code != 0 {
+		t.Fatalf("run falhou: %s", stderr.String())
+	}
+	if want := "\033[31mERROR\033[0m boom\n"; stdout.String() != want {
+		t.Errorf("saída = %q, esperado %q", stdout.String(), want)
+	}
+}
+
+func TestRun_InvalidArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-level", "loud"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("nível inválido deveria retornar 2, obteve %d", code)
+	}
+	if code := run([]string{"-since", "yesterday"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("-since inválido deveria retornar 2, obteve %d", code)
+	}
+	if code := run([]string{"-in-tz", "Mars/Olympus"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("-in-tz inválido deveria retornar 2, obteve %d", code)
+	}
+	if code := run([]string{"/nonexistent.log"}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Errorf("arquivo inexistente deveria retornar 1, obteve %d", code)
+	}
+}
+
+func TestRun_TraceDetails(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	in := `{"time":"2025-08-18T10:00:00Z","level":"INFO","message":"m","trace_id":"0102030405060708090a0b0c0d0e0f10",` +
+		`"span_id":"0102030405060708","trace_flags":"01","trace_sampled":true,"parent_remote":true,` +
+		`"traceparent":"00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"}` + "\n"
+	args := []string{"-color=false", "-format", "{message} {trace_sampled} {parent_remote} {traceparent}"}
+	if code := run(args, strings.NewReader(in), &stdout, &stderr); code != 0 {
+		t.Fatalf("run falhou: %s", stderr.String())
+	}
+	want := "m trace_sampled=true parent_remote=true traceparent=00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01" +
+		" trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708\n"
+	if stdout.String() != want {
+		t.Errorf("saída = %q, esperado %q", stdout.String(), want)
+	}
+}
+
+func TestRun_InputTimeZone(t *testing.T) {
+	in := `{"time":"2025-08-18 08:00:00","level":"INFO","message":"utc"}` + "\n"
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-in-tz", "UTC"}, "08:00:00 utc\n"},
+		{[]string{"-in-tz", "UTC", "-since", "2025-08-18 07:59:00", "-until", "2025-08-18 08:01:00"}, "08:00:00 utc\n"},
+		{[]string{"-in-tz", "UTC", "-since", "2025-08-18 08:00:01"}, ""},
+		{[]string{"-in-tz", "UTC", "-until", "2025-08-18T07:59:59Z"}, ""},
+	}
+	for _, c := range cases {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"-color=false", "-utc", "-time-format", "15:04:05", "-format", "{time} {message}"}, c.args...)
+		if code := run(args, strings.NewReader(in), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%v) = %d: %s", args, code, stderr.String())
+		}
+		if stdout.String() != c.want {
+			t.Errorf("%v: saída = %q, esperado %q", c.args, stdout.String(), c.want)
+		}
+	}
+}
+
+func TestRun_DisplayZoneIndependentOfLayout(t *testing.T) {
+	defer func(loc *time.Location) { time.Local = loc }(time.Local)
+	time.Local = time.FixedZone("BRT", -3*60*60)
+
+	// o mesmo instante em RFC3339, TimeFormatUnix e TimeFormatUnixMilli
+	in := `{"time":"2026-10-16T10:00:00Z","level":"INFO","message":"rfc3339"}
+{"time":1792144800,"level":"INFO","message":"unix"}
+{"time":1792144800000,"level":"INFO","message":"unixmilli"}
+`
+	for _, c := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "07:00:00 rfc3339\n07:00:00 unix\n07:00:00 unixmilli\n"},
+		{[]string{"-utc"}, "10:00:00 rfc3339\n10:00:00 unix\n10:00:00 unixmilli\n"},
+	} {
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"-color=false", "-time-format", "15:04:05", "-format", "{time} {message}"}, c.args...)
+		if code := run(args, strings.NewReader(in), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%v) = %d: %s", args, code, stderr.String())
+		}
+		if stdout.String() != c.want {
+			t.Errorf("%v: saída = %q, esperado %q", c.args, stdout.String(), c.want)
+		}
+	}
+}
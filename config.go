@@ -0,0 +1,446 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/natefinch/lumberjack"
+	"gopkg.in/yaml.v2"
+)
+
+// Config é a forma declarativa das opções do Logger, carregada de arquivo
+// por LoadConfig ou de variáveis de ambiente por NewLoggerFromEnv.
+type Config struct {
+	Level      string          `json:"level" yaml:"level" toml:"level"`
+	Format     string          `json:"format" yaml:"format" toml:"format"`
+	JSON       bool            `json:"json" yaml:"json" toml:"json"`
+	Logfmt     bool            `json:"logfmt" yaml:"logfmt" toml:"logfmt"`
+	Color      bool            `json:"color" yaml:"color" toml:"color"`
+	AppName    string          `json:"app_name" yaml:"app_name" toml:"app_name"`
+	TimeFormat string          `json:"time_format" yaml:"time_format" toml:"time_format"`
+	UTC        bool            `json:"utc" yaml:"utc" toml:"utc"`
+	Output     string          `json:"output" yaml:"output" toml:"output"` // stdout (padrão) ou stderr
+	Rotation   *RotationConfig `json:"rotation" yaml:"rotation" toml:"rotation"`
+	Sinks      []SinkConfig    `json:"sinks" yaml:"sinks" toml:"sinks"`
+	Redaction  []RedactConfig  `json:"redaction" yaml:"redaction" toml:"redaction"`
+}
+
+// RotationConfig equivale aos parâmetros de WithRotatingFile.
+type RotationConfig struct {
+	Filename   string `json:"filename" yaml:"filename" toml:"filename"`
+	MaxSizeMB  int    `json:"max_size_mb" yaml:"max_size_mb" toml:"max_size_mb"`
+	MaxBackups int    `json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+	MaxAgeDays int    `json:"max_age_days" yaml:"max_age_days" toml:"max_age_days"`
+	Compress   bool   `json:"compress" yaml:"compress" toml:"compress"`
+}
+
+// SinkConfig equivale a um Sink; o destino é Output (stdout/stderr) ou
+// Rotation.
+type SinkConfig struct {
+	Output   string          `json:"output" yaml:"output" toml:"output"`
+	Rotation *RotationConfig `json:"rotation" yaml:"rotation" toml:"rotation"`
+	Level    string          `json:"level" yaml:"level" toml:"level"`
+	Encoding string          `json:"encoding" yaml:"encoding" toml:"encoding"` // text, json ou logfmt
+	Format   string          `json:"format" yaml:"format" toml:"format"`
+	Color    bool            `json:"color" yaml:"color" toml:"color"`
+}
+
+// RedactConfig equivale a um RedactRule. Values são expressões regulares;
+// Patterns seleciona padrões prontos: card_number, cpf e email.
+type RedactConfig struct {
+	Keys     []string `json:"keys" yaml:"keys" toml:"keys"`
+	Values   []string `json:"values" yaml:"values" toml:"values"`
+	Patterns []string `json:"patterns" yaml:"patterns" toml:"patterns"`
+	KeepLast int      `json:"keep_last" yaml:"keep_last" toml:"keep_last"`
+	Mask     string   `json:"mask" yaml:"mask" toml:"mask"`
+}
+
+// ConfigError aponta a chave inválida de uma configuração, no caminho do
+// arquivo (ex.: "sinks[1].level") ou no nome da variável de ambiente.
+type ConfigError struct {
+	Key string
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("wslogger: config %s: %v", e.Key, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// LoadConfig lê e valida a configuração de um arquivo YAML (.yaml/.yml),
+// TOML (.toml) ou JSON (.json). Chaves desconhecidas são erro.
+func LoadConfig(path string) (Config, error) {
+	var c Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, &c)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(data), &c)
+		if err == nil {
+			if undecoded := md.Undecoded(); len(undecoded) > 0 {
+				return c, &ConfigError{undecoded[0].String(), errors.New("chave desconhecida")}
+			}
+		}
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	default:
+		return c, fmt.Errorf("wslogger: %s: extensão %q não suportada (use .yaml, .yml, .toml ou .json)", path, ext)
+	}
+	if err != nil {
+		return c, fmt.Errorf("wslogger: %s: %w", path, err)
+	}
+	if _, err := c.Options(); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// NewLoggerFromConfig cria um Logger a partir de c; opts são aplicadas
+// depois e prevalecem.
+func NewLoggerFromConfig(c Config, opts ...Option) (*Logger, error) {
+	cfgOpts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+	return NewLogger(append(cfgOpts, opts...)...), nil
+}
+
+// Variáveis de ambiente lidas por NewLoggerFromEnv.
+const (
+	EnvConfig     = "WSLOGGER_CONFIG" // arquivo base, carregado com LoadConfig
+	EnvLevel      = "WSLOGGER_LEVEL"
+	EnvFormat     = "WSLOGGER_FORMAT"
+	EnvJSON       = "WSLOGGER_JSON"
+	EnvLogfmt     = "WSLOGGER_LOGFMT"
+	EnvColor      = "WSLOGGER_COLOR"
+	EnvAppName    = "WSLOGGER_APP_NAME"
+	EnvTimeFormat = "WSLOGGER_TIME_FORMAT"
+	EnvUTC        = "WSLOGGER_UTC"
+	EnvOutput     = "WSLOGGER_OUTPUT"
+	EnvRotateFile = "WSLOGGER_ROTATION_FILENAME"
+	EnvRotateSize = "WSLOGGER_ROTATION_MAX_SIZE_MB"
+	EnvRotateKeep = "WSLOGGER_ROTATION_MAX_BACKUPS"
+	EnvRotateAge  = "WSLOGGER_ROTATION_MAX_AGE_DAYS"
+	EnvRotateGzip = "WSLOGGER_ROTATION_COMPRESS"
+	EnvRedactKeys = "WSLOGGER_REDACT_KEYS" // lista separada por vírgulas
+)
+
+// NewLoggerFromEnv cria um Logger a partir do ambiente: o arquivo de
+// WSLOGGER_CONFIG, se definido, serve de base e as demais variáveis
+// WSLOGGER_* o sobrescrevem. Sinks e regras de redação por valor só podem
+// vir do arquivo. opts são aplicadas por último.
+func NewLoggerFromEnv(opts ...Option) (*Logger, error) {
+	c, err := configFromEnv(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	return NewLoggerFromConfig(c, opts...)
+}
+
+func configFromEnv(lookup func(string) (string, bool)) (Config, error) {
+	var c Config
+	if path, ok := lookup(EnvConfig); ok && path != "" {
+		var err error
+		if c, err = LoadConfig(path); err != nil {
+			return c, err
+		}
+	}
+	str := func(key string, dst *string) {
+		if v, ok := lookup(key); ok {
+			*dst = v
+		}
+	}
+	var errs []error
+	boolean := func(key string, dst *bool) {
+		if v, ok := lookup(key); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, &ConfigError{key, fmt.Errorf("booleano inválido %q", v)})
+				return
+			}
+			*dst = b
+		}
+	}
+	integer := func(key string, dst *int) {
+		if v, ok := lookup(key); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, &ConfigError{key, fmt.Errorf("inteiro inválido %q", v)})
+				return
+			}
+			*dst = n
+		}
+	}
+
+	str(EnvLevel, &c.Level)
+	str(EnvFormat, &c.Format)
+	boolean(EnvJSON, &c.JSON)
+	boolean(EnvLogfmt, &c.Logfmt)
+	boolean(EnvColor, &c.Color)
+	str(EnvAppName, &c.AppName)
+	str(EnvTimeFormat, &c.TimeFormat)
+	boolean(EnvUTC, &c.UTC)
+	str(EnvOutput, &c.Output)
+	if file, ok := lookup(EnvRotateFile); ok && file != "" {
+		if c.Rotation == nil {
+			c.Rotation = &RotationConfig{}
+		}
+		c.Rotation.Filename = file
+	}
+	if c.Rotation != nil {
+		integer(EnvRotateSize, &c.Rotation.MaxSizeMB)
+		integer(EnvRotateKeep, &c.Rotation.MaxBackups)
+		integer(EnvRotateAge, &c.Rotation.MaxAgeDays)
+		boolean(EnvRotateGzip, &c.Rotation.Compress)
+	}
+	if v, ok := lookup(EnvRedactKeys); ok && v != "" {
+		var keys []string
+		for _, k := range strings.Split(v, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				keys = append(keys, k)
+			}
+		}
+		c.Redaction = append(c.Redaction, RedactConfig{Keys: keys})
+	}
+	if len(errs) > 0 {
+		return c, errors.Join(errs...)
+	}
+	if _, err := c.Options(); err != nil {
+		return c, envKeyError(err)
+	}
+	return c, nil
+}
+
+// envKeyError traduz a chave de um ConfigError para a variável de
+// ambiente correspondente, quando houver.
+func envKeyError(err error) error {
+	envKeys := map[string]string{
+		"level": EnvLevel, "output": EnvOutput,
+		"rotation.filename": EnvRotateFile, "rotation.max_size_mb": EnvRotateSize,
+		"rotation.max_backups": EnvRotateKeep, "rotation.max_age_days": EnvRotateAge,
+	}
+	var ce *ConfigError
+	if errors.As(err, &ce) {
+		if env, ok := envKeys[ce.Key]; ok {
+			return &ConfigError{env, ce.Err}
+		}
+	}
+	return err
+}
+
+// Options valida c e retorna as opções equivalentes. O primeiro erro
+// encontrado é retornado como *ConfigError.
+func (c Config) Options() ([]Option, error) {
+	var opts []Option
+	if c.Level != "" {
+		lv, err := ParseLevel(c.Level)
+		if err != nil {
+			return nil, &ConfigError{"level", err}
+		}
+		opts = append(opts, WithLevel(lv))
+	}
+	if c.Format != "" {
+		opts = append(opts, WithFormat(c.Format))
+	}
+	if c.AppName != "" {
+		opts = append(opts, WithAppName(c.AppName))
+	}
+	if c.TimeFormat != "" {
+		opts = append(opts, WithTimeFormat(c.TimeFormat))
+	}
+	opts = append(opts, WithJSON(c.JSON), WithColor(c.Color), WithUTC(c.UTC))
+	if c.Logfmt {
+		opts = append(opts, WithLogfmt())
+	}
+
+	if c.Output != "" && c.Rotation != nil {
+		return nil, &ConfigError{"output", errors.New("use output ou rotation, não ambos")}
+	}
+	if c.Output != "" {
+		w, err := outputWriter(c.Output)
+		if err != nil {
+			return nil, &ConfigError{"output", err}
+		}
+		opts = append(opts, WithWriter(w))
+	}
+	if c.Rotation != nil {
+		w, err := c.Rotation.writer("rotation")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithWriter(w))
+	}
+
+	sinks := make([]Sink, 0, len(c.Sinks))
+	for i, sc := range c.Sinks {
+		s, err := sc.sink(fmt.Sprintf("sinks[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if len(sinks) > 0 {
+		opts = append(opts, WithSinks(sinks...))
+	}
+
+	rules := make([]RedactRule, 0, len(c.Redaction))
+	for i, rc := range c.Redaction {
+		r, err := rc.rule(fmt.Sprintf("redaction[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	if len(rules) > 0 {
+		opts = append(opts, WithRedaction(rules...))
+	}
+	return opts, nil
+}
+
+// ParseLevel converte um nome de nível padrão (sem diferenciar
+// maiúsculas; "warning" também é aceito) em Level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "TRACE":
+		return LevelTrace, nil
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARN", "WARNING":
+		return LevelWarn, nil
+	case "ERROR":
+		return LevelError, nil
+	case "PANIC":
+		return LevelPanic, nil
+	case "FATAL":
+		return LevelFatal, nil
+	}
+	return 0, fmt.Errorf("nível desconhecido %q", name)
+}
+
+func outputWriter(name string) (io.Writer, error) {
+	switch strings.ToLower(name) {
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+	return nil, fmt.Errorf("saída desconhecida %q (use stdout, stderr ou rotation)", name)
+}
+
+func (rc *RotationConfig) writer(key string) (io.Writer, error) {
+	if rc.Filename == "" {
+		return nil, &ConfigError{key + ".filename", errors.New("obrigatório")}
+	}
+	for _, f := range []struct {
+		name string
+		v    int
+	}{{"max_size_mb", rc.MaxSizeMB}, {"max_backups", rc.MaxBackups}, {"max_age_days", rc.MaxAgeDays}} {
+		if f.v < 0 {
+			return nil, &ConfigError{key + "." + f.name, fmt.Errorf("não pode ser negativo (%d)", f.v)}
+		}
+	}
+	return &lumberjack.Logger{
+		Filename:   rc.Filename,
+		MaxSize:    rc.MaxSizeMB,
+		MaxBackups: rc.MaxBackups,
+		MaxAge:     rc.MaxAgeDays,
+		Compress:   rc.Compress,
+	}, nil
+}
+
+func (sc SinkConfig) sink(key string) (Sink, error) {
+	var s Sink
+	switch {
+	case sc.Rotation != nil && sc.Output != "":
+		return s, &ConfigError{key, errors.New("use output ou rotation, não ambos")}
+	case sc.Rotation != nil:
+		w, err := sc.Rotation.writer(key + ".rotation")
+		if err != nil {
+			return s, err
+		}
+		s.Writer = w
+	case sc.Output != "":
+		w, err := outputWriter(sc.Output)
+		if err != nil {
+			return s, &ConfigError{key + ".output", err}
+		}
+		s.Writer = w
+	default:
+		return s, &ConfigError{key, errors.New("output ou rotation obrigatório")}
+	}
+	if sc.Level != "" {
+		lv, err := ParseLevel(sc.Level)
+		if err != nil {
+			return s, &ConfigError{key + ".level", err}
+		}
+		s.Level = lv
+	}
+	switch strings.ToLower(sc.Encoding) {
+	case "", "text":
+		s.Encoding = EncodingText
+	case "json":
+		s.Encoding = EncodingJSON
+	case "logfmt":
+		s.Encoding = EncodingLogfmt
+	default:
+		return s, &ConfigError{key + ".encoding", fmt.Errorf("encoding desconhecido %q (use text, json ou logfmt)", sc.Encoding)}
+	}
+	s.Format, s.Color = sc.Format, sc.Color
+	return s, nil
+}
+
+var redactPatterns = map[string]*regexp.Regexp{
+	"card_number": PatternCardNumber,
+	"cpf":         PatternCPF,
+	"email":       PatternEmail,
+}
+
+func (rc RedactConfig) rule(key string) (RedactRule, error) {
+	r := RedactRule{Keys: rc.Keys, KeepLast: rc.KeepLast, Mask: rc.Mask}
+	if rc.KeepLast < 0 {
+		return r, &ConfigError{key + ".keep_last", fmt.Errorf("não pode ser negativo (%d)", rc.KeepLast)}
+	}
+	for i, k := range rc.Keys {
+		if _, err := path.Match(strings.ToLower(k), ""); err != nil {
+			return r, &ConfigError{fmt.Sprintf("%s.keys[%d]", key, i), fmt.Errorf("padrão inválido %q: %w", k, err)}
+		}
+	}
+	for i, v := range rc.Values {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return r, &ConfigError{fmt.Sprintf("%s.values[%d]", key, i), err}
+		}
+		r.Values = append(r.Values, re)
+	}
+	for i, p := range rc.Patterns {
+		re, ok := redactPatterns[strings.ToLower(p)]
+		if !ok {
+			return r, &ConfigError{fmt.Sprintf("%s.patterns[%d]", key, i), fmt.Errorf("padrão desconhecido %q (use card_number, cpf ou email)", p)}
+		}
+		r.Values = append(r.Values, re)
+	}
+	if len(r.Keys) == 0 && len(r.Values) == 0 {
+		return r, &ConfigError{key, errors.New("keys, values ou patterns obrigatório")}
+	}
+	return r, nil
+}
@@ -0,0 +1,185 @@
+package wslogger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/lumberjack"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig_Formats(t *testing.T) {
+	files := map[string]string{
+		"log.yaml": `
+level: warn
+app_name: svc
+json: true
+rotation:
+  filename: /tmp/svc.log
+  max_size_mb: 10
+sinks:
+  - output: stderr
+    level: error
+    encoding: logfmt
+redaction:
+  - keys: [password]
+  - patterns: [card_number]
+    keep_last: 4
+`,
+		"log.toml": `
+level = "warn"
+app_name = "svc"
+json = true
+
+[rotation]
+filename = "/tmp/svc.log"
+max_size_mb = 10
+
+[[sinks]]
+output = "stderr"
+level = "error"
+encoding = "logfmt"
+
+[[redaction]]
+keys = ["password"]
+
+[[redaction]]
+patterns = ["card_number"]
+keep_last = 4
+`,
+		"log.json": `{
+  "level": "warn", "app_name": "svc", "json": true,
+  "rotation": {"filename": "/tmp/svc.log", "max_size_mb": 10},
+  "sinks": [{"output": "stderr", "level": "error", "encoding": "logfmt"}],
+  "redaction": [{"keys": ["password"]}, {"patterns": ["card_number"], "keep_last": 4}]
+}`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			c, err := LoadConfig(writeConfig(t, name, content))
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			l, err := NewLoggerFromConfig(c)
+			if err != nil {
+				t.Fatalf("NewLoggerFromConfig: %v", err)
+			}
+			if l.Level() != LevelWarn || l.appName != "svc" || !l.jsonMode {
+				t.Errorf("config não aplicada: level=%v app=%q json=%v", l.Level(), l.appName, l.jsonMode)
+			}
+			if lj, ok := l.writer.(*lumberjack.Logger); !ok || lj.Filename != "/tmp/svc.log" || lj.MaxSize != 10 {
+				t.Errorf("rotação não aplicada: %#v", l.writer)
+			}
+			if len(l.sinks) != 1 || l.sinks[0].Writer != os.Stderr || l.sinks[0].Level != LevelError || l.sinks[0].Encoding != EncodingLogfmt {
+				t.Errorf("sinks = %+v", l.sinks)
+			}
+			if l.redactor == nil || len(l.redactor.rules) != 2 || l.redactor.rules[1].KeepLast != 4 {
+				t.Errorf("redação não aplicada: %+v", l.redactor)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ValidationErrors(t *testing.T) {
+	cases := []struct {
+		name, content, key string
+	}{
+		{"level.yaml", "level: loud\n", "level"},
+		{"sink.yaml", "sinks:\n  - output: stdout\n  - output: stdout\n    encoding: xml\n", "sinks[1].encoding"},
+		{"sinklevel.json", `{"sinks":[{"output":"stdout","level":"nope"}]}`, "sinks[0].level"},
+		{"regex.toml", "[[redaction]]\nvalues = [\"(\"]\n", "redaction[0].values[0]"},
+		{"rotation.yaml", "rotation:\n  max_size_mb: 1\n", "rotation.filename"},
+		{"unknown.toml", "levle = \"info\"\n", "levle"},
+		{"output.json", `{"output":"/var/log/x"}`, "output"},
+	}
+	for _, c := range cases {
+		_, err := LoadConfig(writeConfig(t, c.name, c.content))
+		var ce *ConfigError
+		if !errors.As(err, &ce) || ce.Key != c.key {
+			t.Errorf("%s: esperado ConfigError em %q, obteve %v", c.name, c.key, err)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml": "levle: info\n",
+		"unknown.json": `{"levle":"info"}`,
+	} {
+		if _, err := LoadConfig(writeConfig(t, name, content)); err == nil || !strings.Contains(err.Error(), "levle") {
+			t.Errorf("%s: chave desconhecida deveria ser apontada, obteve %v", name, err)
+		}
+	}
+	if _, err := LoadConfig(writeConfig(t, "log.ini", "")); err == nil {
+		t.Error("extensão não suportada deveria falhar")
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	base := writeConfig(t, "base.yaml", "level: debug\napp_name: base\nformat: \"{level} {message}\"\n")
+	env := map[string]string{
+		EnvConfig:     base,
+		EnvLevel:      "error",
+		EnvColor:      "true",
+		EnvRotateFile: "/tmp/env.log",
+		EnvRotateKeep: "3",
+		EnvRedactKeys: "password, token",
+	}
+	c, err := configFromEnv(func(k string) (string, bool) { v, ok := env[k]; return v, ok })
+	if err != nil {
+		t.Fatalf("configFromEnv: %v", err)
+	}
+	if c.Level != "error" || c.AppName != "base" || c.Format != "{level} {message}" || !c.Color {
+		t.Errorf("variáveis deveriam sobrescrever o arquivo base: %+v", c)
+	}
+	if c.Rotation == nil || c.Rotation.Filename != "/tmp/env.log" || c.Rotation.MaxBackups != 3 {
+		t.Errorf("rotação = %+v", c.Rotation)
+	}
+	if len(c.Redaction) != 1 || strings.Join(c.Redaction[0].Keys, ",") != "password,token" {
+		t.Errorf("redação = %+v", c.Redaction)
+	}
+
+	for _, bad := range []map[string]string{
+		{EnvLevel: "loud"},
+		{EnvJSON: "talvez"},
+		{EnvRotateFile: "/tmp/x.log", EnvRotateSize: "dez"},
+	} {
+		_, err := configFromEnv(func(k string) (string, bool) { v, ok := bad[k]; return v, ok })
+		var ce *ConfigError
+		if !errors.As(err, &ce) || !strings.HasPrefix(ce.Key, "WSLOGGER_") {
+			t.Errorf("%v: erro deveria apontar a variável, obteve %v", bad, err)
+		}
+	}
+}
+
+func TestNewLoggerFromEnv(t *testing.T) {
+	t.Setenv(EnvLevel, "warn")
+	t.Setenv(EnvAppName, "from-env")
+	l, err := NewLoggerFromEnv(WithAppName("from-code"))
+	if err != nil {
+		t.Fatalf("NewLoggerFromEnv: %v", err)
+	}
+	if l.Level() != LevelWarn || l.appName != "from-code" {
+		t.Errorf("level=%v app=%q", l.Level(), l.appName)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]Level{"trace": LevelTrace, "INFO": LevelInfo, " Warning ": LevelWarn, "fatal": LevelFatal} {
+		if got, err := ParseLevel(name); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("nível desconhecido deveria falhar")
+	}
+}
@@ -0,0 +1,62 @@
+package wslogger
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+type ctxFieldsKey struct{}
+
+// ContextWithFields retorna uma cópia de ctx carregando os pares
+// chave/valor kv, somados aos que ctx já carrega. Toda chamada *Ctx (e o
+// SlogHandler) com esse contexto inclui os campos, permitindo que um
+// middleware anexe dados da requisição (request_id, user_id...) uma única
+// vez. Campos de With e da chamada têm precedência em chaves repetidas.
+func ContextWithFields(ctx context.Context, kv ...any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	extra := parseKeyValues(kv...)
+	if len(extra) == 0 {
+		return ctx
+	}
+	prev, _ := ctx.Value(ctxFieldsKey{}).([]KeyValuePair)
+	fields := make([]KeyValuePair, 0, len(prev)+len(extra))
+	fields = append(fields, prev...)
+	return context.WithValue(ctx, ctxFieldsKey{}, append(fields, extra...))
+}
+
+// WithBaggage inclui nos registros os membros do baggage OpenTelemetry do
+// ctx cujas chaves casam com algum dos padrões glob (path.Match), por
+// exemplo "tenant_id" ou "app.*". Sem a opção, nenhum membro é emitido.
+// Os membros aparecem em ordem alfabética, antes dos campos de
+// ContextWithFields.
+func WithBaggage(keys ...string) Option {
+	return func(l *Logger) {
+		l.baggageKeys = append(append([]string(nil), l.baggageKeys...), keys...)
+	}
+}
+
+// contextFields retorna os membros de baggage permitidos e os campos de
+// ContextWithFields presentes em ctx.
+func (l *Logger) contextFields(ctx context.Context) []KeyValuePair {
+	if ctx == nil {
+		return nil
+	}
+	var out []KeyValuePair
+	if len(l.baggageKeys) > 0 {
+		members := baggage.FromContext(ctx).Members()
+		sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+		for _, m := range members {
+			if matchAny(l.baggageKeys, m.Key()) {
+				out = append(out, KeyValuePair{m.Key(), m.Value()})
+			}
+		}
+	}
+	if fields, ok := ctx.Value(ctxFieldsKey{}).([]KeyValuePair); ok {
+		out = append(out, fields...)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestContextWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"))
+
+	ctx := ContextWithFields(context.Background(), "request_id", "r-1", "user", "ana")
+	ctx = ContextWithFields(ctx, "route", "/orders")
+	l.With("svc", "api").InfoCtx(ctx, "handled", "user", "bia")
+	l.Info("sem contexto")
+
+	want := "handled request_id=r-1 user=bia route=/orders svc=api\nsem contexto\n"
+	if buf.String() != want {
+		t.Errorf("saída = %q, esperado %q", buf.String(), want)
+	}
+	if got := ContextWithFields(ctx); got != ctx {
+		t.Error("sem pares, o contexto deveria ser devolvido intacto")
+	}
+}
+
+func TestWithBaggage(t *testing.T) {
+	tenant, _ := baggage.NewMember("tenant_id", "acme")
+	region, _ := baggage.NewMember("app.region", "sa-east-1")
+	secret, _ := baggage.NewMember("session", "s3cr3t")
+	bag, _ := baggage.New(tenant, region, secret)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	ctx = ContextWithFields(ctx, "request_id", "r-1")
+
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithBaggage("tenant_id", "app.*"))
+	l.InfoCtx(ctx, "text")
+	l.Slog().InfoContext(ctx, "slog")
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.HasSuffix(line, " app.region=sa-east-1 tenant_id=acme request_id=r-1") {
+			t.Errorf("linha = %q", line)
+		}
+		if strings.Contains(line, "session") {
+			t.Errorf("membro fora da lista não deveria aparecer: %q", line)
+		}
+	}
+
+	buf.Reset()
+	NewLogger(WithWriter(&buf), WithFormat("{message} {extra}")).InfoCtx(ctx, "sem allowlist")
+	if got := strings.TrimSpace(buf.String()); got != "sem allowlist request_id=r-1" {
+		t.Errorf("baggage só deveria sair com WithBaggage: %q", got)
+	}
+}
+
+func TestContextFields_JSONAndNilContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true), WithBaggage("*"))
+	ctx := ContextWithFields(nil, "attempt", 2)
+	l.InfoCtx(ctx, "retry")
+	l.InfoCtx(nil, "nil ctx")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"extra":{"attempt":2}`) {
+		t.Errorf("saída = %q", buf.String())
+	}
+}
@@ -0,0 +1,204 @@
+package wslogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxErrorChain limita a cadeia percorrida, protegendo contra ciclos em
+// implementações de Unwrap.
+const maxErrorChain = 32
+
+// Err retorna o campo "error" com err, para uso entre os argumentos de
+// log: l.Error("falha ao salvar", wslogger.Err(err), "id", id). Um error
+// passado sem chave tem o mesmo efeito.
+func Err(err error) KeyValuePair {
+	return KeyValuePair{"error", err}
+}
+
+// WithErrorStack ativa a captura da pilha do callsite em registros que
+// contêm erros; a pilha é incluída no campo de cada erro.
+func WithErrorStack(enable bool) Option {
+	return func(l *Logger) { l.errorStack = enable }
+}
+
+// errorInfo é a forma estruturada de um erro na saída: mensagem, tipo
+// concreto, cadeia de causas (errors.Unwrap e errors.Join, em
+// profundidade) e a pilha opcional.
+type errorInfo struct {
+	Message string      `json:"message"`
+	Type    string      `json:"type"`
+	Chain   []errorLink `json:"chain,omitempty"`
+	Stack   []string    `json:"stack,omitempty"`
+
+	frames []runtime.Frame
+}
+
+type errorLink struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+func newErrorInfo(err error, stack []runtime.Frame) *errorInfo {
+	info := &errorInfo{Message: errorMessage(err), Type: fmt.Sprintf("%T", err), frames: stack}
+	var walk func(error)
+	walk = func(e error) {
+		if isNilPointer(e) {
+			return
+		}
+		var causes []error
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			causes = []error{u.Unwrap()}
+		case interface{ Unwrap() []error }:
+			causes = u.Unwrap()
+		}
+		for _, c := range causes {
+			if c == nil || len(info.Chain) >= maxErrorChain {
+				continue
+			}
+			info.Chain = append(info.Chain, errorLink{errorMessage(c), fmt.Sprintf("%T", c)})
+			walk(c)
+		}
+	}
+	walk(err)
+	for _, fr := range stack {
+		info.Stack = append(info.Stack, fr.Function+" "+fr.File+":"+strconv.Itoa(fr.Line))
+	}
+	return info
+}
+
+// errorMessage retorna err.Error() sem derrubar o logger (ver safeString).
+func errorMessage(err error) string {
+	return safeString(err, "Error", err.Error)
+}
+
+// safeString chama fn, o método Error ou String de v, como fmt faz: se o
+// método entra em pânico, um ponteiro nil (um error nil tipado, por
+// exemplo) vira "<nil>" e os demais casos, a marca %!v(PANIC=...) de fmt.
+func safeString(v any, method string, fn func() string) (s string) {
+	defer func() {
+		if p := recover(); p != nil {
+			if isNilPointer(v) {
+				s = "<nil>"
+				return
+			}
+			s = fmt.Sprintf("%%!v(PANIC=%s method: %v)", method, p)
+		}
+	}()
+	return fn()
+}
+
+// isNilPointer informa se v é um ponteiro nil embrulhado numa interface.
+func isNilPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// asErrorInfo retorna a forma estruturada de v quando v é um erro. No
+// JSON, erros que implementam json.Marshaler mantêm a própria
+// serialização.
+func asErrorInfo(v any, stack []runtime.Frame, jsonMode bool) (*errorInfo, bool) {
+	switch val := v.(type) {
+	case *errorInfo:
+		return val, true
+	case error:
+		if _, ok := val.(json.Marshaler); ok && jsonMode {
+			return nil, false
+		}
+		return newErrorInfo(val, stack), true
+	}
+	return nil, false
+}
+
+// textParts renderiza o erro como sufixo compacto, com pair formatando
+// cada par: key=mensagem key.type=T [key.chain=T1>T2]
+// [key.stack=a.go:1>b.go:2].
+func (info *errorInfo) textParts(key string, pair func(key string, value any) string) []string {
+	parts := []string{
+		pair(key, info.Message),
+		pair(key+".type", info.Type),
+	}
+	if len(info.Chain) > 0 {
+		types := make([]string, len(info.Chain))
+		for i, c := range info.Chain {
+			types[i] = c.Type
+		}
+		parts = append(parts, pair(key+".chain", strings.Join(types, ">")))
+	}
+	if len(info.frames) > 0 {
+		locs := make([]string, len(info.frames))
+		for i, fr := range info.frames {
+			locs[i] = filepath.Base(fr.File) + ":" + strconv.Itoa(fr.Line)
+		}
+		parts = append(parts, pair(key+".stack", strings.Join(locs, ">")))
+	}
+	return parts
+}
+
+// errorfArgs formata a mensagem de um método *f. Com %w, os erros
+// embrulhados são anexados como campo "error", preservando a cadeia que o
+// texto da mensagem achataria.
+func errorfArgs(format string, args ...any) []any {
+	err := fmt.Errorf(format, args...)
+	var cause error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		cause = u.Unwrap()
+	case interface{ Unwrap() []error }:
+		cause = errors.Join(u.Unwrap()...)
+	}
+	if cause == nil {
+		return []any{err.Error()}
+	}
+	return []any{err.Error(), Err(cause)}
+}
+
+// hasErrorField informa se algum campo carrega um erro.
+func hasErrorField(fields []KeyValuePair) bool {
+	for _, kv := range fields {
+		if _, ok := kv.value.(error); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// captureStack retorna a pilha a partir do callsite pc, sem os frames do
+// runtime. Retorna nil se pc não está na pilha atual.
+func captureStack(pc uintptr) []runtime.Frame {
+	if pc == 0 {
+		return nil
+	}
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	pcs = pcs[:n]
+	start := -1
+	for i, p := range pcs {
+		if p == pc {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	var out []runtime.Frame
+	frames := runtime.CallersFrames(pcs[start:])
+	for {
+		fr, more := frames.Next()
+		if !strings.HasPrefix(fr.Function, "runtime.") {
+			out = append(out, fr)
+		}
+		if !more {
+			break
+		}
+	}
+	return out
+}
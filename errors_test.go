@@ -0,0 +1,194 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type jsonErrorRecord struct {
+	Message string `json:"message"`
+	Extra   struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Chain   []struct {
+				Message string `json:"message"`
+				Type    string `json:"type"`
+			} `json:"chain"`
+			Stack []string `json:"stack"`
+		} `json:"error"`
+	} `json:"extra"`
+}
+
+func TestLogger_ErrJSONChain(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true))
+
+	_, openErr := os.Open("/nonexistent/config.yaml")
+	err := fmt.Errorf("load config: %w", openErr)
+	l.Error("startup failed", Err(err), "attempt", 3)
+
+	var rec jsonErrorRecord
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+		t.Fatalf("JSON inválido: %v (%s)", e, buf.String())
+	}
+	got := rec.Extra.Error
+	if got.Message != err.Error() || got.Type != "*fmt.wrapError" {
+		t.Errorf("erro = %+v", got)
+	}
+	if len(got.Chain) != 2 || got.Chain[0].Type != "*fs.PathError" || got.Chain[1].Type != "syscall.Errno" {
+		t.Errorf("cadeia inesperada: %+v", got.Chain)
+	}
+	if len(got.Stack) != 0 {
+		t.Errorf("pilha só deveria ser capturada com WithErrorStack: %v", got.Stack)
+	}
+	if !strings.Contains(buf.String(), `"attempt":3`) {
+		t.Errorf("demais extras deveriam ser preservados: %s", buf.String())
+	}
+}
+
+func TestLogger_ErrorDetectedWithoutKey(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"))
+
+	l.Warn("retrying", errors.New("timeout"), "n", 2)
+	want := "retrying error=timeout error.type=*errors.errorString n=2"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+// ptrError dereferencia o receptor em Error, como a maioria dos erros
+// declarados com receptor ponteiro.
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+func TestLogger_TypedNilError(t *testing.T) {
+	var nilErr *ptrError
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"))
+	l.Info("x", "err", nilErr)
+	l.Info("x", error(nilErr))
+	want := "x err=<nil> err.type=*wslogger.ptrError\nx error=<nil> error.type=*wslogger.ptrError\n"
+	if buf.String() != want {
+		t.Errorf("texto = %q\nesperado %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	l = NewLogger(WithWriter(&buf), WithJSON(true))
+	l.Info("x", error(nilErr), "wrapped", fmt.Errorf("ctx: %w", nilErr))
+	var rec struct {
+		Extra map[string]errorInfo `json:"extra"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("JSON inválido: %v", err)
+	}
+	if got := rec.Extra["error"]; got.Message != "<nil>" || got.Type != "*wslogger.ptrError" {
+		t.Errorf("error = %+v", got)
+	}
+	if got := rec.Extra["wrapped"]; got.Message != "ctx: <nil>" || len(got.Chain) != 1 || got.Chain[0].Message != "<nil>" {
+		t.Errorf("wrapped = %+v", got)
+	}
+}
+
+func TestLogger_MultipleErrorsWithoutKey(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"))
+
+	l.Info("x", errors.New("a"), errors.New("b"), errors.New("c"))
+	want := "x error=a error.type=*errors.errorString error.1=b error.1.type=*errors.errorString" +
+		" error.2=c error.2.type=*errors.errorString"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+func TestLogger_ErrorTextJoinChain(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"))
+
+	err := errors.Join(fs.ErrNotExist, fmt.Errorf("ctx: %w", fs.ErrPermission))
+	l.Error("cleanup", "cause", err)
+
+	out := buf.String()
+	for _, want := range []string{
+		`cause="file does not exist`,
+		"cause.type=*errors.joinError",
+		"cause.chain=*errors.errorString>*fmt.wrapError>*errors.errorString",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída deveria conter %q: %q", want, out)
+		}
+	}
+	if strings.Count(out, "\n") != 1 {
+		t.Errorf("erro multilinha deveria ocupar uma linha: %q", out)
+	}
+}
+
+func TestLogger_ErrorfWrapAttachesCause(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true))
+
+	l.Errorf("save order %d: %w", 7, fs.ErrPermission)
+
+	var rec jsonErrorRecord
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+		t.Fatalf("JSON inválido: %v (%s)", e, buf.String())
+	}
+	if rec.Message != "save order 7: permission denied" {
+		t.Errorf("message = %q", rec.Message)
+	}
+	if got := rec.Extra.Error; got.Message != "permission denied" {
+		t.Errorf("%%w deveria anexar a causa como campo error: %+v", got)
+	}
+
+	buf.Reset()
+	l.Errorf("no wrap %d", 1)
+	if strings.Contains(buf.String(), `"extra"`) {
+		t.Errorf("sem %%w não deveria haver extras: %s", buf.String())
+	}
+}
+
+func TestLogger_ErrorStack(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true), WithErrorStack(true))
+
+	l.Error("failed", Err(errors.New("boom")))
+
+	var rec jsonErrorRecord
+	if e := json.Unmarshal(buf.Bytes(), &rec); e != nil {
+		t.Fatalf("JSON inválido: %v (%s)", e, buf.String())
+	}
+	stack := rec.Extra.Error.Stack
+	if len(stack) == 0 || !strings.Contains(stack[0], "TestLogger_ErrorStack") || !strings.Contains(stack[0], "errors_test.go:") {
+		t.Errorf("pilha deveria começar no callsite: %v", stack)
+	}
+
+	buf.Reset()
+	text := NewLogger(WithWriter(&buf), WithFormat("{extra}"), WithErrorStack(true))
+	text.Info("no error here", "k", "v")
+	text.Error("failed", Err(errors.New("boom")))
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Contains(lines[0], "stack") ||
+		!strings.Contains(lines[1], "error.stack=errors_test.go:") {
+		t.Errorf("pilha em texto inesperada: %q", buf.String())
+	}
+}
+
+func TestLogger_RedactionAppliesToErrors(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{extra}"),
+		WithRedaction(RedactRule{Values: []*regexp.Regexp{PatternEmail}}))
+
+	l.Error("send failed", fmt.Errorf("notify: %w", fmt.Errorf("invalid recipient john@example.com")))
+	if out := buf.String(); strings.Contains(out, "john@example.com") || !strings.Contains(out, `error="notify: invalid recipient ***"`) {
+		t.Errorf("mensagem do erro deveria ser mascarada: %q", out)
+	}
+}
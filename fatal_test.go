@@ -0,0 +1,135 @@
+package wslogger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// syncRecorder registra a ordem entre escrita, Sync e saída do processo.
+type syncRecorder struct {
+	strings.Builder
+	events []string
+}
+
+func (s *syncRecorder) Write(p []byte) (int, error) {
+	s.events = append(s.events, "write")
+	return s.Builder.Write(p)
+}
+
+func (s *syncRecorder) Sync() error {
+	s.events = append(s.events, "sync")
+	return nil
+}
+
+func TestLogger_FatalSyncsAndExits(t *testing.T) {
+	w := &syncRecorder{}
+	exitCode := -1
+	l := NewLogger(
+		WithWriter(w),
+		WithColor(false),
+		WithLevel(LevelError),
+		WithExitFunc(func(code int) {
+			w.events = append(w.events, "exit")
+			exitCode = code
+		}),
+	)
+
+	l.Fatal("fatal error", "reason", "disk")
+	if exitCode != 1 {
+		t.Fatalf("exit deveria ser chamado com código 1, obteve %d", exitCode)
+	}
+	if got := strings.Join(w.events, ","); got != "write,sync,exit" {
+		t.Errorf("ordem esperada write,sync,exit; obteve %s", got)
+	}
+	if out := w.String(); !strings.Contains(out, "[FATAL] fatal error") || !strings.Contains(out, "reason=disk") {
+		t.Errorf("saída FATAL inesperada: %q", out)
+	}
+
+	// Fatalf e FatalCtx também encerram, mesmo com nível mínimo alto.
+	l.SetLevel(LevelFatal + 1)
+	exitCode = -1
+	l.Fatalf("code %d", 2)
+	if exitCode != 1 || !strings.Contains(w.String(), "code 2") {
+		t.Errorf("Fatalf deveria registrar e encerrar: exit=%d out=%q", exitCode, w.String())
+	}
+	exitCode = -1
+	l.FatalCtx(context.Background(), "with ctx")
+	if exitCode != 1 || !strings.Contains(w.String(), "with ctx") {
+		t.Errorf("FatalCtx deveria registrar e encerrar: exit=%d out=%q", exitCode, w.String())
+	}
+}
+
+func TestLogger_PanicLogsThenPanics(t *testing.T) {
+	var buf strings.Builder
+	bw := bufio.NewWriter(&buf)
+	l := NewLogger(WithWriter(bw), WithJSON(true))
+
+	defer func() {
+		r := recover()
+		if r != "panic 42" {
+			t.Fatalf("valor do panic inesperado: %v", r)
+		}
+		// o bufio.Writer precisa ter sido descarregado antes do panic
+		var record map[string]any
+		if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+			t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+		}
+		if record["level"] != "PANIC" || record["message"] != "panic 42" {
+			t.Errorf("registro PANIC inesperado: %v", record)
+		}
+	}()
+	l.Panicf("panic %d", 42)
+	t.Fatal("Panicf deveria ter chamado panic")
+}
+
+func TestLogger_PanicValueRedacted(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"),
+		WithRedaction(RedactRule{Values: []*regexp.Regexp{PatternCardNumber}, KeepLast: 4}))
+
+	defer func() {
+		if r := recover(); r != "card ***1111" {
+			t.Errorf("valor do panic deveria ser redigido: %v", r)
+		}
+		if got := strings.TrimSpace(buf.String()); got != "card ***1111" {
+			t.Errorf("registro = %q", got)
+		}
+	}()
+	l.Panic("card 4111 1111 1111 1111")
+}
+
+func TestLogger_FatalFlushesRotatingFile(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "fatal.log")
+	exited := false
+	l := NewLogger(
+		WithMultiWriterTo(&strings.Builder{}, logFile, 1, 1, 1, false),
+		WithExitFunc(func(int) { exited = true }),
+	)
+
+	l.Fatal("last words")
+	if !exited {
+		t.Fatal("exit deveria ter sido chamado")
+	}
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("falha ao ler arquivo de log: %v", err)
+	}
+	if !strings.Contains(string(data), "last words") {
+		t.Errorf("arquivo rotativo deveria conter a última linha: %q", data)
+	}
+}
+
+func TestLogger_FatalPanicColors(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithColor(true), WithExitFunc(func(int) {}))
+	l.Fatal("colored")
+	if !strings.Contains(buf.String(), colorBoldRed+"FATAL"+colorReset) {
+		t.Errorf("FATAL deveria ser vermelho em negrito: %q", buf.String())
+	}
+}
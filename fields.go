@@ -0,0 +1,65 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// dedupeFields remove chaves repetidas mantendo a posição da primeira
+// ocorrência e o valor da última, de modo que extras da chamada
+// sobrescrevem campos fixos sem alterar a ordem de saída.
+func dedupeFields(fields []KeyValuePair) []KeyValuePair {
+	if len(fields) < 2 {
+		return fields
+	}
+	index := make(map[string]int, len(fields))
+	out := make([]KeyValuePair, 0, len(fields))
+	for _, kv := range fields {
+		if i, ok := index[kv.key]; ok {
+			out[i].value = kv.value
+			continue
+		}
+		index[kv.key] = len(out)
+		out = append(out, kv)
+	}
+	return out
+}
+
+// orderedFields serializa uma lista de pares como objeto JSON preservando
+// a ordem dos campos. Os valores passam por jsonValue.
+type orderedFields []KeyValuePair
+
+func (f orderedFields) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, kv := range f {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(kv.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(jsonValue(kv.value))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// String renderiza os pares como um map do fmt, mantendo a ordem; usado
+// no modo texto para valores aninhados reconstruídos pela redação.
+func (f orderedFields) String() string {
+	parts := make([]string, len(f))
+	for i, kv := range f {
+		parts[i] = kv.key + ":" + fmt.Sprint(kv.value)
+	}
+	return "map[" + strings.Join(parts, " ") + "]"
+}
@@ -3,15 +3,15 @@ module github.com/thiagozs/go-wslogger
 go 1.23.6
 
 require (
-	go.opentelemetry.io/otel/sdk v1.35.0
-	go.opentelemetry.io/otel/trace v1.35.0
+	github.com/BurntSushi/toml v1.5.0
+	go.opentelemetry.io/otel/log v0.13.0
+	go.opentelemetry.io/otel/sdk v1.37.0
+	go.opentelemetry.io/otel/sdk/log v0.13.0
+	go.opentelemetry.io/otel/trace v1.37.0
+	gopkg.in/yaml.v2 v2.4.0
 )
 
-require (
-	github.com/BurntSushi/toml v1.5.0 // indirect
-	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
-)
+require gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 
 require (
 	github.com/go-logr/logr v1.4.3 // indirect
@@ -19,7 +19,7 @@ require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/natefinch/lumberjack v2.0.0+incompatible
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
-	go.opentelemetry.io/otel v1.35.0
-	go.opentelemetry.io/otel/metric v1.35.0 // indirect
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/metric v1.37.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 )
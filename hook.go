@@ -0,0 +1,119 @@
+package wslogger
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// Record é a representação estruturada de um registro entregue aos hooks,
+// já com caller e IDs de trace resolvidos e antes da codificação em texto
+// ou JSON. Os hooks podem alterar os campos exportados e os pares
+// chave/valor.
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Caller  string
+	TraceID string
+	SpanID  string
+
+	TraceFlags   string // flags W3C em hexa ("01" = amostrado)
+	TraceState   string // tracestate W3C
+	ParentSpanID string // span pai, quando o span do ctx é do SDK
+	RemoteParent bool   // o pai (ou o contexto, sem span local) veio de outro processo
+
+	fields []KeyValuePair  // ordenados e sem chaves repetidas
+	stack  []runtime.Frame // pilha do callsite, com WithErrorStack
+	frame  runtime.Frame   // callsite, formatado por cada encoder (ver callerFor)
+	caller string          // Caller como resolvido, para detectar alteração por hooks
+}
+
+// NumFields retorna a quantidade de pares chave/valor do registro.
+func (r *Record) NumFields() int { return len(r.fields) }
+
+// Fields chama f para cada par, na ordem de saída, até f retornar false.
+func (r *Record) Fields(f func(key string, value any) bool) {
+	for _, kv := range r.fields {
+		if !f(kv.key, kv.value) {
+			return
+		}
+	}
+}
+
+// Field retorna o valor da chave key, se presente.
+func (r *Record) Field(key string) (any, bool) {
+	for _, kv := range r.fields {
+		if kv.key == key {
+			return kv.value, true
+		}
+	}
+	return nil, false
+}
+
+// AddField define o valor de key: substitui no lugar se a chave já existe
+// ou acrescenta o par ao final.
+func (r *Record) AddField(key string, value any) {
+	for i := range r.fields {
+		if r.fields[i].key == key {
+			r.fields[i].value = value
+			return
+		}
+	}
+	r.fields = append(r.fields, KeyValuePair{key, value})
+}
+
+// RemoveField remove a chave key, se presente.
+func (r *Record) RemoveField(key string) {
+	for i, kv := range r.fields {
+		if kv.key == key {
+			r.fields = append(r.fields[:i:i], r.fields[i+1:]...)
+			return
+		}
+	}
+}
+
+// Hook inspeciona ou altera um registro antes da escrita. Retornar false
+// descarta o registro (PANIC e FATAL ainda interrompem o programa).
+type Hook interface {
+	Fire(ctx context.Context, r *Record) bool
+}
+
+// HookFunc adapta uma função ao Hook.
+type HookFunc func(ctx context.Context, r *Record) bool
+
+func (f HookFunc) Fire(ctx context.Context, r *Record) bool { return f(ctx, r) }
+
+// WithHooks registra hooks executados, na ordem, para cada registro
+// emitido, igualmente nos modos texto e JSON. O primeiro hook que retornar
+// false interrompe a cadeia e descarta o registro. Hooks são chamados de
+// forma concorrente pelas goroutines que logam.
+func WithHooks(hooks ...Hook) Option {
+	return func(l *Logger) {
+		l.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], hooks...)
+	}
+}
+
+func (l *Logger) runHooks(ctx context.Context, r *Record) bool {
+	for _, h := range l.hooks {
+		if !h.Fire(ctx, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// LogRecord emite um Record já montado, por exemplo ao reprocessar logs
+// de outra origem. Caller, IDs de trace e campos são usados como estão;
+// o nível mínimo, hooks, redação e encoders se aplicam normalmente, mas
+// PANIC e FATAL não interrompem o programa.
+func (l *Logger) LogRecord(ctx context.Context, r Record) {
+	if !l.Enabled(r.Level) {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	r.fields = append([]KeyValuePair(nil), r.fields...)
+	l.emit(l.config(), &r, ctx)
+}
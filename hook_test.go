@@ -0,0 +1,139 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLogger_HookMutatesRecord(t *testing.T) {
+	hook := HookFunc(func(_ context.Context, r *Record) bool {
+		r.Message = strings.ToUpper(r.Message)
+		r.AddField("env", "prod")
+		r.AddField("user", "***")
+		r.RemoveField("debug")
+		return true
+	})
+	for _, jsonMode := range []bool{false, true} {
+		var buf bytes.Buffer
+		l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithJSON(jsonMode), WithHooks(hook))
+		l.Info("login", "user", "john", "debug", true)
+
+		out := strings.TrimSpace(buf.String())
+		if !jsonMode {
+			if out != "LOGIN user=*** env=prod" {
+				t.Errorf("texto = %q", out)
+			}
+			continue
+		}
+		var got struct {
+			Message string         `json:"message"`
+			Extra   map[string]any `json:"extra"`
+		}
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("JSON inválido: %v", err)
+		}
+		if got.Message != "LOGIN" || got.Extra["user"] != "***" || got.Extra["env"] != "prod" || len(got.Extra) != 2 {
+			t.Errorf("JSON = %s", out)
+		}
+		if !strings.Contains(out, `"extra":{"user":"***","env":"prod"}`) {
+			t.Errorf("ordem dos campos não preservada: %s", out)
+		}
+	}
+}
+
+func TestLogger_HookVeto(t *testing.T) {
+	var buf bytes.Buffer
+	var calls int
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"), WithHooks(
+		HookFunc(func(_ context.Context, r *Record) bool {
+			_, health := r.Field("healthcheck")
+			return !health
+		}),
+		HookFunc(func(context.Context, *Record) bool {
+			calls++
+			return true
+		}),
+	))
+	l.Info("GET /health", "healthcheck", true)
+	l.Info("GET /orders")
+
+	if buf.String() != "GET /orders\n" {
+		t.Errorf("saída = %q", buf.String())
+	}
+	if calls != 1 {
+		t.Errorf("hooks após o veto não deveriam rodar, calls=%d", calls)
+	}
+}
+
+func TestLogger_HookReceivesResolvedRecord(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID,
+	}))
+
+	var got Record
+	var keys []string
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithClock(fixedClock), WithHooks(
+		HookFunc(func(_ context.Context, r *Record) bool {
+			got = *r
+			r.Fields(func(k string, _ any) bool {
+				keys = append(keys, k)
+				return true
+			})
+			return true
+		}),
+	)).With("svc", "api")
+	l.WarnCtx(ctx, "slow", "ms", 120)
+
+	if got.Level != LevelWarn || got.Message != "slow" || !got.Time.Equal(fixedClock()) {
+		t.Errorf("record inesperado: %+v", got)
+	}
+	if got.TraceID != traceID.String() || got.SpanID != spanID.String() {
+		t.Errorf("IDs de trace não resolvidos: %q %q", got.TraceID, got.SpanID)
+	}
+	if !strings.HasPrefix(got.Caller, "hook_test.go:") {
+		t.Errorf("caller = %q", got.Caller)
+	}
+	if strings.Join(keys, ",") != "svc,ms" || got.NumFields() != 2 {
+		t.Errorf("campos = %v", keys)
+	}
+}
+
+func TestLogger_HooksInheritedByWith(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithHooks(
+		HookFunc(func(_ context.Context, r *Record) bool {
+			r.AddField("hooked", true)
+			return true
+		}),
+	))
+	l.With("k", "v").Info("child")
+	if got := strings.TrimSpace(buf.String()); got != "child k=v hooked=true" {
+		t.Errorf("saída = %q", got)
+	}
+}
+
+func TestLogger_LogRecord(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	l := NewLogger(WithWriter(&buf), WithFormat("[{caller}] [{level}] {message} {extra}"),
+		WithLevel(LevelInfo), WithExitFunc(func(int) { exited = true }))
+
+	r := Record{Time: fixedClock(), Level: LevelFatal, Message: "replayed", Caller: "main.go:7", TraceID: "t1"}
+	r.AddField("k", "v")
+	l.LogRecord(context.Background(), r)
+	l.LogRecord(context.Background(), Record{Level: LevelDebug, Message: "filtered"})
+
+	if got := strings.TrimSpace(buf.String()); got != "[main.go:7] [FATAL] replayed k=v trace_id=t1" {
+		t.Errorf("saída = %q", got)
+	}
+	if exited {
+		t.Error("LogRecord não deveria encerrar o programa")
+	}
+}
@@ -0,0 +1,129 @@
+package wslogger
+
+import "fmt"
+
+// Level representa a severidade de um registro de log. Valores maiores
+// indicam maior severidade; o espaçamento entre os níveis segue o mesmo
+// padrão do log/slog para facilitar conversões.
+type Level int
+
+// Níveis padrão.
+const (
+	LevelTrace Level = -8
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+	LevelPanic Level = 12 // registra e chama panic
+	LevelFatal Level = 16 // registra e encerra o processo
+)
+
+// String retorna o nome do nível como aparece na saída do logger.
+func (lv Level) String() string {
+	switch lv {
+	case LevelTrace:
+		return "TRACE"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelPanic:
+		return "PANIC"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(lv))
+	}
+}
+
+// WithLevel define o nível mínimo emitido pelo logger. Registros com
+// severidade inferior são descartados antes de qualquer formatação.
+func WithLevel(lv Level) Option {
+	return func(l *Logger) { l.level.Store(int64(lv)) }
+}
+
+// SetLevel altera o nível mínimo em tempo de execução. É seguro chamá-lo
+// concorrentemente com as chamadas de log.
+func (l *Logger) SetLevel(lv Level) {
+	l.level.Store(int64(lv))
+}
+
+// Level retorna o nível mínimo atualmente configurado.
+func (l *Logger) Level() Level {
+	return Level(l.level.Load())
+}
+
+// Enabled informa se um registro no nível lv seria emitido. PANIC e FATAL
+// estão sempre habilitados, já que interrompem o fluxo do programa.
+func (l *Logger) Enabled(lv Level) bool {
+	return lv >= l.Level() || lv >= LevelPanic
+}
+
+// levelDef descreve um nível no registro do Logger.
+type levelDef struct {
+	name  string
+	color string // código ANSI usado quando a saída é colorida
+}
+
+// levelRegistry mapeia severidades para nome e cor. O Logger trata o
+// registro como imutável: RegisterLevel cria uma cópia com a alteração.
+type levelRegistry map[Level]levelDef
+
+var defaultLevels = levelRegistry{
+	LevelTrace: {"TRACE", colorBlue},
+	LevelDebug: {"DEBUG", colorCyan},
+	LevelInfo:  {"INFO", colorGreen},
+	LevelWarn:  {"WARN", colorYellow},
+	LevelError: {"ERROR", colorRed},
+	LevelPanic: {"PANIC", colorMagenta},
+	LevelFatal: {"FATAL", colorBoldRed},
+}
+
+// name retorna o nome registrado para lv ou, na ausência, lv.String().
+func (r levelRegistry) name(lv Level) string {
+	if d, ok := r[lv]; ok {
+		return d.name
+	}
+	return lv.String()
+}
+
+// getColorCode retorna o código ANSI registrado para lv ou "" se não houver.
+func (r levelRegistry) getColorCode(lv Level) string {
+	return r[lv].color
+}
+
+// WithCustomLevel registra um nível próprio (ex.: AUDIT, SECURITY, NOTICE)
+// com sua severidade numérica e cor ANSI (ex.: "\033[35m"; vazio para sem
+// cor). Também pode renomear ou recolorir um nível padrão. Use Log/LogCtx
+// para emitir registros no nível.
+func WithCustomLevel(lv Level, name, color string) Option {
+	return func(l *Logger) { l.registerLevel(lv, name, color) }
+}
+
+// RegisterLevel é o equivalente de WithCustomLevel em tempo de execução;
+// é seguro para uso concorrente com as chamadas de log.
+func (l *Logger) RegisterLevel(lv Level, name, color string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.registerLevel(lv, name, color)
+}
+
+func (l *Logger) registerLevel(lv Level, name, color string) {
+	levels := make(levelRegistry, len(l.levels)+1)
+	for k, v := range l.levels {
+		levels[k] = v
+	}
+	levels[lv] = levelDef{name: name, color: color}
+	l.levels = levels
+}
+
+// LevelName retorna o nome com que lv aparece na saída deste logger.
+func (l *Logger) LevelName(lv Level) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.levels.name(lv)
+}
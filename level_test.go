@@ -0,0 +1,171 @@
+package wslogger
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogger_LevelFiltering(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(
+		WithWriter(&buf),
+		WithColor(false),
+		WithLevel(LevelWarn),
+	)
+
+	l.Debug("debug descartado")
+	l.Info("info descartado")
+	l.Infof("infof %s", "descartado")
+	l.InfoCtx(context.Background(), "infoctx descartado")
+	l.Warn("warn emitido")
+	l.Errorf("error %s", "emitido")
+
+	out := buf.String()
+	if strings.Contains(out, "descartado") {
+		t.Errorf("níveis abaixo de WARN não deveriam aparecer: %q", out)
+	}
+	if !strings.Contains(out, "warn emitido") || !strings.Contains(out, "error emitido") {
+		t.Errorf("níveis WARN/ERROR deveriam aparecer: %q", out)
+	}
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithColor(false))
+
+	if l.Level() != LevelDebug {
+		t.Fatalf("nível padrão deveria ser DEBUG, obteve %v", l.Level())
+	}
+
+	l.SetLevel(LevelError)
+	if l.Level() != LevelError {
+		t.Fatalf("SetLevel falhou: esperado ERROR, obteve %v", l.Level())
+	}
+	l.Warn("warn descartado")
+	l.WrapGoroutine().Warn("goroutine descartado")
+	if buf.Len() != 0 {
+		t.Errorf("nenhuma saída esperada com nível ERROR: %q", buf.String())
+	}
+
+	l.SetLevel(LevelDebug)
+	l.Debug("debug emitido")
+	if !strings.Contains(buf.String(), "debug emitido") {
+		t.Errorf("DEBUG deveria ser emitido após SetLevel: %q", buf.String())
+	}
+}
+
+func TestLogger_SetLevelConcurrent(t *testing.T) {
+	l := NewLogger(WithWriter(io.Discard), WithLevel(LevelError))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				l.SetLevel(LevelInfo)
+			} else {
+				l.SetLevel(LevelError)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = l.Enabled(LevelWarn)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLevel_String(t *testing.T) {
+	cases := map[Level]string{
+		LevelDebug: "DEBUG",
+		LevelInfo:  "INFO",
+		LevelWarn:  "WARN",
+		LevelError: "ERROR",
+		LevelPanic: "PANIC",
+		LevelFatal: "FATAL",
+		Level(2):   "LEVEL(2)",
+	}
+	for lv, want := range cases {
+		if got := lv.String(); got != want {
+			t.Errorf("Level(%d).String() = %q, esperado %q", int(lv), got, want)
+		}
+	}
+}
+
+func BenchmarkLogger_DisabledLevel(b *testing.B) {
+	l := NewLogger(WithWriter(io.Discard), WithLevel(LevelError))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("descartado", "i", i)
+	}
+}
+
+func TestLogger_TraceLevel(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithColor(false))
+
+	l.Trace("trace descartado")
+	if buf.Len() != 0 {
+		t.Fatalf("TRACE deveria estar abaixo do nível padrão: %q", buf.String())
+	}
+
+	l.SetLevel(LevelTrace)
+	l.Tracef("trace %d", 1)
+	l.WrapGoroutine().Trace("trace goroutine")
+	out := buf.String()
+	if !strings.Contains(out, "[TRACE] trace 1") || !strings.Contains(out, "[TRACE] trace goroutine") {
+		t.Errorf("TRACE deveria ser emitido: %q", out)
+	}
+}
+
+func TestLogger_CustomLevels(t *testing.T) {
+	const (
+		LevelNotice   Level = 2
+		LevelAudit    Level = 6
+		LevelSecurity Level = 10
+	)
+	var buf strings.Builder
+	l := NewLogger(
+		WithWriter(&buf),
+		WithColor(true),
+		WithLevel(LevelNotice),
+		WithCustomLevel(LevelNotice, "NOTICE", ""),
+		WithCustomLevel(LevelAudit, "AUDIT", "\033[35m"),
+	)
+	l.RegisterLevel(LevelSecurity, "SECURITY", "\033[41m")
+
+	l.Log(LevelAudit, "user deleted", "user", "john")
+	l.LogCtx(context.Background(), LevelSecurity, "login blocked")
+	l.Log(LevelNotice, "notice")
+	l.Log(LevelInfo, "info descartado")
+
+	out := buf.String()
+	if !strings.Contains(out, "\033[35mAUDIT\033[0m") || !strings.Contains(out, "\033[35muser\033[0m=john") {
+		t.Errorf("AUDIT deveria usar a cor registrada: %q", out)
+	}
+	if !strings.Contains(out, "\033[41mSECURITY\033[0m") {
+		t.Errorf("SECURITY deveria usar a cor registrada: %q", out)
+	}
+	if !strings.Contains(out, "[NOTICE\033[0m]") {
+		t.Errorf("NOTICE sem cor deveria aparecer: %q", out)
+	}
+	if strings.Contains(out, "info descartado") {
+		t.Errorf("INFO está abaixo de NOTICE e deveria ser filtrado: %q", out)
+	}
+	if got := l.LevelName(LevelSecurity); got != "SECURITY" {
+		t.Errorf("LevelName = %q, esperado SECURITY", got)
+	}
+
+	// O registro é copiado para loggers derivados e aparece no JSON.
+	buf.Reset()
+	child := l.With("k", "v")
+	child.SetJSON(true)
+	child.Log(LevelAudit, "json audit")
+	if !strings.Contains(buf.String(), `"level":"AUDIT"`) {
+		t.Errorf("JSON deveria usar o nome registrado: %q", buf.String())
+	}
+}
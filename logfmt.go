@@ -0,0 +1,94 @@
+package wslogger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WithLogfmt ativa o encoder logfmt: uma linha
+// time=... level=... app=... caller=... msg="..." [trace_id=... span_id=...]
+// seguida dos extras, com aspas e escapes apenas onde necessário. O
+// template de WithFormat e as cores são ignorados; WithJSON tem
+// precedência.
+func WithLogfmt() Option {
+	return func(l *Logger) { l.logfmt = true }
+}
+
+// encodeLogfmt serializa o Record em logfmt.
+func (l *Logger) encodeLogfmt(cfg config, r *Record) string {
+	var b strings.Builder
+	pair := func(key string, value any) string {
+		return logfmtKey(key) + "=" + logfmtValue(value)
+	}
+	add := func(p string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p)
+	}
+	timeStr, _ := l.formatTime(r.Time)
+	add(pair("time", timeStr))
+	add(pair("level", cfg.levels.name(r.Level)))
+	add(pair("app", cfg.appName))
+	add(pair("caller", l.callerFor(r, false)))
+	add(pair("msg", r.Message))
+	if r.TraceID != "" {
+		add(pair("trace_id", r.TraceID))
+	}
+	if r.SpanID != "" {
+		add(pair("span_id", r.SpanID))
+	}
+	if l.traceDetails {
+		for _, kv := range r.traceDetails() {
+			add(pair(kv.key, kv.value))
+		}
+	}
+	for _, kv := range r.fields {
+		if info, ok := asErrorInfo(kv.value, r.stack, false); ok {
+			for _, p := range info.textParts(kv.key, pair) {
+				add(p)
+			}
+			continue
+		}
+		add(pair(kv.key, kv.value))
+	}
+	return b.String()
+}
+
+// logfmtKey troca por '_' os caracteres que não podem aparecer numa chave
+// logfmt (espaços, '=', aspas e controles).
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtValue formata v e o coloca entre aspas, com escapes, quando
+// vazio ou contendo espaços, '=', aspas, barras invertidas ou caracteres
+// de controle.
+func logfmtValue(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		if v == nil {
+			return "null"
+		}
+		s = fmt.Sprint(v)
+	}
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLogger_Logfmt(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithLogfmt(), WithAppName("svc"), WithClock(fixedClock),
+		WithTimeFormat(TimeFormatRFC3339), WithUTC(true), WithColor(true),
+		WithCallerFormatter(func(*runtime.Frame) string { return "main.go:42" }))
+
+	l.Info(`said "hi"`, "path", `C:\tmp`, "q", "a=b", "n", 3, "empty", "", "multi", "a\nb", "nil", nil)
+
+	want := `time=2025-08-18T18:04:05Z level=INFO app=svc caller=main.go:42 msg="said \"hi\"" ` +
+		`path="C:\\tmp" q="a=b" n=3 empty="" multi="a\nb" nil=null`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %s\nesperado %s", got, want)
+	}
+}
+
+func TestLogger_LogfmtTraceAndErrors(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID,
+	}))
+
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithLogfmt())
+	l.ErrorCtx(ctx, "failed", Err(errors.New("disk full")), "bad key", 1)
+
+	out := buf.String()
+	for _, want := range []string{
+		" msg=failed trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 ",
+		` error="disk full" error.type=*errors.errorString bad_key=1`,
+		`time="`, // o formato padrão de hora contém espaço
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída deveria conter %q: %q", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") {
+		t.Errorf("logfmt não deveria ter cores: %q", out)
+	}
+}
+
+func TestLogger_LogfmtSink(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithSinks(Sink{Writer: &buf, Encoding: EncodingLogfmt}))
+	l.Info("via sink", "k", "v")
+	if out := buf.String(); !strings.Contains(out, ` msg="via sink" k=v`) || !strings.Contains(out, " level=INFO ") {
+		t.Errorf("sink logfmt = %q", out)
+	}
+}
+
+func TestLogfmtValue(t *testing.T) {
+	cases := map[any]string{
+		"plain":      "plain",
+		"with tab\t": `"with tab\t"`,
+		"ação":       "ação",
+		true:         "true",
+		1.5:          "1.5",
+	}
+	for in, want := range cases {
+		if got := logfmtValue(in); got != want {
+			t.Errorf("logfmtValue(%v) = %s, esperado %s", in, got, want)
+		}
+	}
+}
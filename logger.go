@@ -4,33 +4,101 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
 	"io"
 	"os"
-	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/natefinch/lumberjack"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // Option define uma função de configuração para o Logger.
 type Option func(*Logger)
 
-// Logger customizado.
+// Logger customizado. É seguro para uso concorrente: a configuração é
+// protegida por mu e cada linha é escrita no writer com uma única chamada
+// Write, serializada por writeMu.
 type Logger struct {
+	mu               sync.RWMutex
+	writeMu          *sync.Mutex
 	writer           io.Writer
 	format           string
 	appName          string
 	color            bool
 	jsonMode         bool
+	logfmt           bool
 	includeSpanAttrs bool
+	level            atomic.Int64
+	fields           []KeyValuePair // campos fixos anexados via With
+	callerFormat     CallerFormatFn // nil usa o formato padrão de cada modo
+	callerSkip       int
+	timeFormat       string
+	utc              bool
+	clock            func() time.Time
+	exitFn           func(code int)
+	levels           levelRegistry // nomes e cores dos níveis; copy-on-write sob mu
+	asyncSize        int
+	asyncPolicy      AsyncPolicy
+	async            *asyncWriter // fila do modo assíncrono, compartilhada com derivados
+	sampler          Sampler
+	summaryInterval  time.Duration
+	summary          *sampleSummary // resumos de descartes, compartilhado com derivados
+	hooks            []Hook
+	redactor         *redactor
+	errorStack       bool
+	sinks            []Sink // substituem writer, formato e modo quando presentes
+	spanOpts         spanOptions
+	baggageKeys      []string // padrões de chaves de baggage emitidas
+	traceDetails     bool
+	spanAttrFilter   SpanAttributeFilter
+	spanAttrSource   SpanAttributeSource
+}
+
+// config é um snapshot da configuração mutável do Logger, tirado uma vez
+// por registro para que setters concorrentes não afetem uma linha pela metade.
+type config struct {
+	format           string
+	appName          string
+	color            bool
+	jsonMode         bool
+	logfmt           bool
+	includeSpanAttrs bool
+	levels           levelRegistry
+}
+
+func (l *Logger) config() config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return config{
+		format:           l.format,
+		appName:          l.appName,
+		color:            l.color,
+		jsonMode:         l.jsonMode,
+		logfmt:           l.logfmt,
+		includeSpanAttrs: l.includeSpanAttrs,
+		levels:           l.levels,
+	}
+}
+
+// write emite uma linha completa no writer. A linha e o '\n' final são
+// enviados numa única chamada Write sob writeMu, de modo que linhas de
+// goroutines diferentes nunca se intercalam, mesmo em writers não seguros.
+// No modo assíncrono a linha é enfileirada para a goroutine de escrita.
+func (l *Logger) write(w io.Writer, line string) {
+	buf := make([]byte, 0, len(line)+1)
+	buf = append(buf, line...)
+	buf = append(buf, '\n')
+	if l.async != nil && l.async.enqueue(w, buf) {
+		return
+	}
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	_, _ = w.Write(buf)
 }
 
 // WithWriter permite configurar o destino de saída do logger.
@@ -42,21 +110,6 @@ func WithWriter(w io.Writer) Option {
 	}
 }
 
-// Flags para formato do caller
-const (
-	CallerFlagFull   uint8 = iota // função,arquivo:linha
-	CallerFlagFunc                // função
-	CallerFlagFcLine              // função:linha
-	CallerFlagPkg                 // pacote
-	CallerFlagPkgFnl              // pacote,arquivo:linha
-	CallerFlagFnlFcn              // arquivo:linha,função
-	CallerFlagFnLine              // arquivo:linha
-	CallerFlagFcName              // nome da função
-	CallerFlagFpLine              // caminho/arquivo:linha
-)
-
-type CallerFormatFn func(*runtime.Frame) string
-
 // Valores default.
 const (
 	defaultFormat  = "[{time}] [{app_name}] [{caller}] [{level}] {message} {extra}"
@@ -65,285 +118,75 @@ const (
 
 // Códigos ANSI para cores.
 const (
-	colorReset  = "\033[0m"
-	colorGreen  = "\033[32m"
-	colorYellow = "\033[33m"
-	colorRed    = "\033[31m"
-	colorCyan   = "\033[36m"
+	colorReset   = "\033[0m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorRed     = "\033[31m"
+	colorCyan    = "\033[36m"
+	colorBlue    = "\033[34m"
+	colorMagenta = "\033[35m"
+	colorBoldRed = "\033[1;31m"
 )
 
-// findFuncForLine tenta descobrir o nome da função que contém a linha `line` no arquivo `path`.
-// Retorna o nome simples da função (sem pacote) e true se encontrada.
-func findFuncForLine(path string, line int) (string, bool) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, path, nil, 0)
-	if err != nil {
-		// tenta localizar por basename no repo (prefere arquivos em examples/)
-		base := filepath.Base(path)
-		var matches []string
-		_ = filepath.Walk(".", func(p string, info os.FileInfo, err error) error {
-			if err == nil && info != nil && !info.IsDir() && filepath.Base(p) == base {
-				matches = append(matches, p)
-			}
-			return nil
-		})
-		if len(matches) == 0 {
-			return "", false
-		}
-		// prefer files dentro de examples/
-		chosen := matches[0]
-		for _, m := range matches {
-			if strings.Contains(m, string(filepath.Separator)+"examples"+string(filepath.Separator)) {
-				chosen = m
-				break
+// encodeJSON serializa o Record como objeto JSON.
+func (l *Logger) encodeJSON(cfg config, r *Record) string {
+	_, jsonTime := l.formatTime(r.Time)
+	// erros viram objetos aninhados; o Record não é alterado
+	fields, copied := r.fields, false
+	for i, kv := range r.fields {
+		if info, ok := asErrorInfo(kv.value, r.stack, true); ok {
+			if !copied {
+				fields, copied = append([]KeyValuePair(nil), r.fields...), true
 			}
-		}
-		f, err = parser.ParseFile(fset, chosen, nil, 0)
-		if err != nil {
-			return "", false
+			fields[i].value = info
 		}
 	}
-	for _, decl := range f.Decls {
-		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
-			start := fset.Position(fn.Pos()).Line
-			end := fset.Position(fn.End()).Line
-			if line >= start && line <= end {
-				// retorna nome da função sem receiver
-				if fn.Name != nil {
-					return fn.Name.Name, true
-				}
-			}
-		}
+	out := logJSON{
+		Time:    jsonTime,
+		Level:   cfg.levels.name(r.Level),
+		App:     cfg.appName,
+		Caller:  l.callerFor(r, true),
+		Message: r.Message,
+		TraceID: r.TraceID,
+		SpanID:  r.SpanID,
+		Extra:   orderedFields(fields),
 	}
-	return "", false
-}
-
-// findLogCallLineInFunc procura dentro do arquivo `path` pela função `funcName`
-// e tenta encontrar a primeira chamada a um método Info/Warn/Error/Debug para
-// inferir a linha do log. Retorna a linha e true se encontrada.
-func findLogCallLineInFunc(path, funcName string) (int, bool) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, path, nil, 0)
-	if err != nil {
-		// tenta localizar por basename (prefere arquivos em examples/)
-		base := filepath.Base(path)
-		var matches []string
-		_ = filepath.Walk(".", func(p string, info os.FileInfo, err error) error {
-			if err == nil && info != nil && !info.IsDir() && filepath.Base(p) == base {
-				matches = append(matches, p)
-			}
-			return nil
-		})
-		if len(matches) == 0 {
-			return 0, false
-		}
-		chosen := matches[0]
-		for _, m := range matches {
-			if strings.Contains(m, string(filepath.Separator)+"examples"+string(filepath.Separator)) {
-				chosen = m
-				break
-			}
-		}
-		f, err = parser.ParseFile(fset, chosen, nil, 0)
-		if err != nil {
-			return 0, false
-		}
+	if l.traceDetails && r.TraceID != "" {
+		sampled, remote := r.TraceSampled(), r.RemoteParent
+		out.TraceFlags = r.traceFlags().String()
+		out.TraceSampled = &sampled
+		out.TraceState = r.TraceState
+		out.ParentSpanID = r.ParentSpanID
+		out.ParentRemote = &remote
+		out.Traceparent = r.Traceparent()
 	}
-	var found bool
-	var foundLine int
-	ast.Inspect(f, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-		// procura por chamadas dentro de função com o nome
-		if fd, ok := n.(*ast.FuncDecl); ok && fd.Name != nil && fd.Name.Name == funcName {
-			ast.Inspect(fd.Body, func(n2 ast.Node) bool {
-				if call, ok := n2.(*ast.CallExpr); ok {
-					if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
-						if ident, ok := sel.X.(*ast.Ident); ok {
-							// ex: log.Info(...)
-							name := sel.Sel.Name
-							if (ident.Name == "log" || ident.Name == "logger") && (name == "Info" || name == "Warn" || name == "Error" || name == "Debug" || name == "Infof") {
-								pos := fset.Position(call.Pos())
-								foundLine = pos.Line
-								found = true
-								return false
-							}
-						}
-					}
-				}
-				return true
-			})
-			return false
-		}
-		return true
-	})
-	return foundLine, found
-}
-
-// findGoStmtLineInFunc procura a linha do primeiro 'go'
-// statement dentro da função funcName no arquivo path.
-func findGoStmtLineInFunc(path, funcName string) (int, bool) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, path, nil, 0)
-	if err != nil {
-		base := filepath.Base(path)
-		var matches []string
-		_ = filepath.Walk(".", func(p string, info os.FileInfo, err error) error {
-			if err == nil && info != nil && !info.IsDir() && filepath.Base(p) == base {
-				matches = append(matches, p)
-			}
-			return nil
-		})
-		if len(matches) == 0 {
-			return 0, false
-		}
-		chosen := matches[0]
-		for _, m := range matches {
-			if strings.Contains(m, string(filepath.Separator)+"examples"+string(filepath.Separator)) {
-				chosen = m
-				break
-			}
-		}
-		f, err = parser.ParseFile(fset, chosen, nil, 0)
-		if err != nil {
-			return 0, false
-		}
-		path = chosen
-	}
-	var found bool
-	var foundLine int
-	ast.Inspect(f, func(n ast.Node) bool {
-		if found {
-			return false
-		}
-		if fd, ok := n.(*ast.FuncDecl); ok && fd.Name != nil && fd.Name.Name == funcName {
-			ast.Inspect(fd.Body, func(n2 ast.Node) bool {
-				if gs, ok := n2.(*ast.GoStmt); ok {
-					pos := fset.Position(gs.Go)
-					foundLine = pos.Line
-					found = true
-					return false
-				}
-				return true
-			})
-			return false
-		}
-		return true
-	})
-	return foundLine, found
-}
-
-func (l *Logger) logInternalJSON(level, msg string,
-	extras []KeyValuePair, ctx context.Context) {
-	now := time.Now()
-	var traceID, spanID string
-	var extraMap map[string]string
-	if span := trace.SpanFromContext(ctx); span != nil {
-		sc := span.SpanContext()
-		if sc.IsValid() {
-			traceID = sc.TraceID().String()
-			spanID = sc.SpanID().String()
-		}
-		if l.includeSpanAttrs {
-			extraMap = spanAttributesToMap(span)
-		}
-	}
-	if extraMap == nil {
-		extraMap = make(map[string]string)
-	}
-	// Normaliza extras e captura caller preferido (goroutine_caller) se presente
-	caller := ""
-	normalized := make(map[string]string)
-	for _, kv := range extras {
-		v := strings.ReplaceAll(kv.value, "\n", "")
-		v = strings.ReplaceAll(v, "\r", "")
-		v = strings.TrimSpace(v)
-		normalized[kv.key] = v
-	}
-	if v, ok := normalized["goroutine_caller"]; ok {
-		if strings.Contains(v, ":") {
-			parts := strings.Split(v, ":")
-			last := parts[len(parts)-1]
-			path := strings.Join(parts[:len(parts)-1], ":")
-			if ln, err := strconv.Atoi(last); err == nil {
-				// Temos file:line => tenta descobrir função que contém essa linha
-				if fn, found := findFuncForLine(path, ln); found {
-					caller = filepath.Base(path) + ":" + fn + ":" + fmt.Sprintf("%d", ln)
-				} else {
-					caller = filepath.Base(path) + ":" + fmt.Sprintf("%d", ln)
-				}
-				normalized["goroutine_caller"] = caller
-			} else {
-				// Temos file:func => tenta localizar linha do call de log dentro da função
-				if ln, found := findLogCallLineInFunc(path, last); found {
-					caller = filepath.Base(path) + ":" + last + ":" + fmt.Sprintf("%d", ln)
-				} else {
-					// fallback: usa a linha do caller atual
-					if gc := l.getCaller(3); strings.Contains(gc, ":") {
-						linePart := strings.Split(gc, ":")[1]
-						caller = filepath.Base(path) + ":" + last + ":" + linePart
-					} else {
-						caller = filepath.Base(path) + ":" + last
-					}
-				}
-				normalized["goroutine_caller"] = caller
-			}
-		} else {
-			normalized["goroutine_caller"] = filepath.Base(v)
-			caller = normalized["goroutine_caller"]
-		}
-		// usa sempre o goroutine_caller normalizado como caller principal
-		if nc, ok2 := normalized["goroutine_caller"]; ok2 {
-			caller = nc
-		}
-	}
-	if caller == "" {
-		// fallback: use __callsite as fallback for JSON path
-		if cs, ok := normalized["__callsite"]; ok {
-			if strings.Contains(cs, ":") {
-				parts := strings.Split(cs, ":")
-				line := parts[len(parts)-1]
-				path := strings.Join(parts[:len(parts)-1], ":")
-				caller = filepath.Base(path) + ":" + line
-			} else {
-				caller = normalized["__callsite"]
-			}
-		} else {
-			caller = l.getCaller(3)
-		}
-	}
-	// merge normalized extras into extraMap so JSON output uses normalized values
-	for k, v := range normalized {
-		extraMap[k] = v
-	}
-	// não exponha __callsite no JSON
-	delete(extraMap, "__callsite")
-	record := logJSON{
-		Time:    now.Format("2006-01-02 15:04:05"),
-		Level:   level,
-		App:     l.appName,
-		Caller:  caller,
-		Message: msg,
-		TraceID: traceID,
-		SpanID:  spanID,
-		Extra:   extraMap,
-	}
-	data, _ := json.Marshal(record)
-	fmt.Fprintln(l.writer, string(data))
+	data, _ := json.Marshal(out)
+	return string(data)
 }
 
 // ==== Options ======
 
 func NewLogger(opts ...Option) *Logger {
 	l := &Logger{
-		writer:  os.Stdout,
-		format:  defaultFormat,
-		appName: defaultAppName,
-	}
+		writeMu:    new(sync.Mutex),
+		writer:     os.Stdout,
+		format:     defaultFormat,
+		appName:    defaultAppName,
+		timeFormat: TimeFormatDefault,
+		exitFn:     os.Exit,
+		levels:     defaultLevels,
+	}
+	l.level.Store(int64(LevelDebug))
 	for _, opt := range opts {
 		opt(l)
 	}
+	if l.asyncSize > 0 {
+		l.async = newAsyncWriter(l.writeMu, l.asyncSize, l.asyncPolicy)
+	}
+	if cs, ok := l.sampler.(clockSetter); ok && l.clock != nil {
+		cs.setClock(l.clock)
+	}
+	l.summary = newSampleSummary(l)
 	return l
 }
 
@@ -364,7 +207,10 @@ func WithFormat(format string) Option {
 	return func(l *Logger) { l.format = format }
 }
 
-// Ativa/desativa captura automática de atributos do span OTel
+// Ativa/desativa captura automática de atributos do span OTel (texto,
+// JSON e logfmt); os atributos vêm de WithSpanAttributeSource e são
+// filtrados por WithSpanAttributeFilter. Sem uma fonte nada é capturado:
+// spans do SDK exigem registrar otelsdk.SpanAttributeProcessor.
 func WithSpanAttributes(enable bool) Option {
 	return func(l *Logger) { l.includeSpanAttrs = enable }
 }
@@ -391,7 +237,7 @@ func WithMultiWriter(filename string, maxSizeMB, maxBackups,
 func WithMultiWriterTo(w io.Writer, filename string, maxSizeMB,
 	maxBackups, maxAgeDays int, compress bool) Option {
 	return func(l *Logger) {
-		l.writer = io.MultiWriter(
+		l.writer = newMultiWriter(
 			w,
 			&lumberjack.Logger{
 				Filename:   filename,
@@ -404,10 +250,12 @@ func WithMultiWriterTo(w io.Writer, filename string, maxSizeMB,
 	}
 }
 
-// KeyValuePair representa um par chave-valor.
+// KeyValuePair representa um par chave-valor. O valor é mantido com o tipo
+// original: o modo texto o formata com formatValue e o modo JSON o
+// serializa nativamente.
 type KeyValuePair struct {
 	key   string
-	value string
+	value any
 }
 
 func parseLogArgs(args ...any) (string, []KeyValuePair) {
@@ -415,14 +263,42 @@ func parseLogArgs(args ...any) (string, []KeyValuePair) {
 		return "", nil
 	}
 	mainMsg := fmt.Sprint(args[0])
+	return mainMsg, parseKeyValues(args[1:]...)
+}
+
+// parseKeyValues converte uma lista chave, valor, ... em pares. Um
+// KeyValuePair (ex.: Err) ou um error na posição de chave ocupa uma única
+// posição; o error vira o campo "error" e os seguintes, "error.1",
+// "error.2"..., para que nenhum seja descartado. Uma chave sem valor
+// correspondente é ignorada.
+func parseKeyValues(kv ...any) []KeyValuePair {
 	var extras []KeyValuePair
-	n := len(args)
-	for i := 1; i+1 < n; i += 2 {
-		key := fmt.Sprint(args[i])
-		value := formatValue(args[i+1])
-		extras = append(extras, KeyValuePair{key, value})
+	bareErrors := 0
+	n := len(kv)
+	for i := 0; i < n; {
+		switch v := kv[i].(type) {
+		case KeyValuePair:
+			extras = append(extras, v)
+			i++
+			continue
+		case error:
+			kvp := Err(v)
+			if bareErrors > 0 {
+				kvp.key += "." + strconv.Itoa(bareErrors)
+			}
+			extras = append(extras, kvp)
+			bareErrors++
+			i++
+			continue
+		}
+		if i+1 >= n {
+			break
+		}
+		key := fmt.Sprint(kv[i])
+		extras = append(extras, KeyValuePair{key, kv[i+1]})
+		i += 2
 	}
-	return mainMsg, extras
+	return extras
 }
 
 func formatValue(v any) string {
@@ -436,59 +312,71 @@ func formatValue(v any) string {
 	return fmt.Sprint(v)
 }
 
-func getColorCode(level string) string {
-	switch level {
-	case "INFO":
-		return colorGreen
-	case "WARN":
-		return colorYellow
-	case "ERROR":
-		return colorRed
-	case "DEBUG":
-		return colorCyan
-	default:
-		return ""
+// normalizeText remove quebras de linha e espaços nas pontas de um valor
+// já formatado, para que cada registro ocupe uma única linha.
+func normalizeText(s string) string {
+	s = strings.ReplaceAll(s, "\n", "")
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.TrimSpace(s)
+}
+
+// jsonValue prepara um extra para o JSON preservando o tipo nativo:
+// números, booleanos, nil, slices, maps, structs e json.Marshaler são
+// serializados como tal. Strings são normalizadas como no modo texto e
+// erros viram sua mensagem. Valores que o encoding/json não suporta
+// (funções, canais, NaN...) caem para a representação textual.
+func jsonValue(v any) any {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return normalizeText(val)
+	case error:
+		if _, ok := val.(json.Marshaler); !ok {
+			return errorMessage(val)
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
 	}
+	return json.RawMessage(data)
 }
 
-func (l *Logger) formatMessage(level, msg, extra string, t time.Time,
-	traceID, spanID, caller string) string {
+func (l *Logger) formatMessage(cfg config, r *Record, extra string) string {
+	timeStr, _ := l.formatTime(r.Time)
 
+	level := cfg.levels.name(r.Level)
 	colorCode := ""
-	if l.color {
-		colorCode = getColorCode(level)
+	if cfg.color {
+		colorCode = cfg.levels.getColorCode(r.Level)
 		level = colorCode + level + colorReset
 	}
 
-	// Aplica cor apenas ao valor de trace_id/span_id se colorido
-	if traceID != "" {
-		val := strings.TrimPrefix(traceID, "trace_id=")
-		if l.color {
-			traceID = colorCode + "trace_id" + colorCode + colorReset + "=" + val
-		} else {
-			traceID = "trace_id=" + val
+	// Aplica cor apenas à chave dos campos de trace se colorido
+	tracePair := func(key, val string) string {
+		if cfg.color {
+			return colorCode + key + colorReset + "=" + val
 		}
+		return key + "=" + val
 	}
-	if spanID != "" {
-		val := strings.TrimPrefix(spanID, "span_id=")
-		if l.color {
-			spanID = colorCode + "span_id" + colorCode + colorReset + "=" + val
-		} else {
-			spanID = "span_id=" + val
-		}
+	traceID, spanID := "", ""
+	if r.TraceID != "" {
+		traceID = tracePair("trace_id", r.TraceID)
+	}
+	if r.SpanID != "" {
+		spanID = tracePair("span_id", r.SpanID)
 	}
 
 	replacements := map[string]string{
-		"{time}":     t.Format("2006-01-02 15:04:05"),
-		"{app_name}": l.appName,
-		"{caller}":   caller,
+		"{time}":     timeStr,
+		"{app_name}": cfg.appName,
+		"{caller}":   l.callerFor(r, false),
 		"{level}":    level,
-		"{message}":  msg,
-		"{trace_id}": traceID,
-		"{span_id}":  spanID,
+		"{message}":  r.Message,
 	}
 
-	formatted := l.format
+	formatted := cfg.format
 	for placeholder, value := range replacements {
 		if value == "" {
 			continue
@@ -496,18 +384,27 @@ func (l *Logger) formatMessage(level, msg, extra string, t time.Time,
 		formatted = strings.ReplaceAll(formatted, placeholder, value)
 	}
 
+	// placeholders de trace somem quando o registro não tem trace
+	traceValues := map[string]string{"trace_id": traceID, "span_id": spanID}
+	for _, kv := range r.traceDetails() {
+		traceValues[kv.key] = tracePair(kv.key, fmt.Sprint(kv.value))
+	}
+	for _, key := range tracePlaceholders {
+		formatted = strings.ReplaceAll(formatted, "{"+key+"}", traceValues[key])
+	}
+
 	// Remove {extra} de forma segura e sem cortar caracteres
 	formatted = strings.ReplaceAll(formatted, "{extra}", extra)
 	// Trim espaços redundantes
 	formatted = strings.ReplaceAll(formatted, "  ", " ")
 	formatted = strings.TrimSpace(formatted)
 
-	// Garante que trace_id/span_id sempre aparecem se OTel estiver presente
-	// (ou seja, se traceID ou spanID não estão vazios)
+	// Garante que trace_id/span_id sempre aparecem se OTel estiver presente,
+	// a menos que o template ou um extra já os inclua
 	appendFields := []string{}
 	if traceID != "" || spanID != "" {
-		hasTrace := strings.Contains(formatted, "trace_id=")
-		hasSpan := strings.Contains(formatted, "span_id=")
+		hasTrace := strings.Contains(cfg.format, "{trace_id}") || strings.Contains(" "+formatted, " trace_id=")
+		hasSpan := strings.Contains(cfg.format, "{span_id}") || strings.Contains(" "+formatted, " span_id=")
 		if !hasTrace && traceID != "" {
 			appendFields = append(appendFields, traceID)
 		}
@@ -528,180 +425,234 @@ func (l *Logger) formatMessage(level, msg, extra string, t time.Time,
 	return formatted
 }
 
-// getCaller com suporte a flags e função customizada
-func (l *Logger) getCaller(skip int) string {
-	// Itera os frames a partir do skip informado e retorna o primeiro
-	// que não pertence ao pacote do logger nem ao runtime/testing.
-	pcs := make([]uintptr, 64)
-	n := runtime.Callers(skip+2, pcs)
-	if n == 0 {
-		return "unknown"
-	}
-	frames := runtime.CallersFrames(pcs[:n])
-	for {
-		fr, more := frames.Next()
-		if fr.Function == "" {
-			if !more {
-				break
-			}
-			continue
-		}
-		// filtragens básicas para pular frames internos
-		if strings.Contains(fr.Function, "github.com/thiagozs/go-wslogger") || strings.HasPrefix(fr.Function, "runtime.") || strings.Contains(fr.File, "/testing/") {
-			if !more {
-				break
-			}
-			continue
-		}
-		// extrai nome simples da função (por último segmento após '.')
-		parts := strings.Split(fr.Function, ".")
-		fn := parts[len(parts)-1]
-		return fmt.Sprintf("%s:%s:%d", filepath.Base(fr.File), fn, fr.Line)
-	}
-	return "unknown"
+// tracePlaceholders são os placeholders de trace do template de texto.
+var tracePlaceholders = []string{
+	"trace_id", "span_id", "trace_flags", "trace_sampled", "trace_state",
+	"parent_span_id", "parent_remote", "traceparent",
 }
 
 // JSON struct para output
 type logJSON struct {
-	Time    string            `json:"time"`
-	Level   string            `json:"level"`
-	App     string            `json:"app_name"`
-	Caller  string            `json:"caller"`
-	Message string            `json:"message"`
-	TraceID string            `json:"trace_id,omitempty"`
-	SpanID  string            `json:"span_id,omitempty"`
-	Extra   map[string]string `json:"extra,omitempty"`
-}
-
-// Captura atributos do Span OTel para map[string]string
-func spanAttributesToMap(span trace.Span) map[string]string {
-	out := make(map[string]string)
-	if s, ok := span.(interface{ Attributes() []attribute.KeyValue }); ok {
-		for _, attr := range s.Attributes() {
-			out[string(attr.Key)] = attr.Value.Emit()
-		}
-	}
-	return out
+	Time    any    `json:"time"`
+	Level   string `json:"level"`
+	App     string `json:"app_name"`
+	Caller  string `json:"caller"`
+	Message string `json:"message"`
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+
+	// WithTraceDetails
+	TraceFlags   string `json:"trace_flags,omitempty"`
+	TraceSampled *bool  `json:"trace_sampled,omitempty"`
+	TraceState   string `json:"trace_state,omitempty"`
+	ParentSpanID string `json:"parent_span_id,omitempty"`
+	ParentRemote *bool  `json:"parent_remote,omitempty"`
+	Traceparent  string `json:"traceparent,omitempty"`
+
+	Extra orderedFields `json:"extra,omitempty"`
+}
+
+// entry agrupa os dados de um registro já processados pelo front-end
+// (métodos do Logger ou o slog.Handler) antes da formatação.
+type entry struct {
+	time   time.Time
+	level  Level
+	msg    string
+	extras []KeyValuePair
+	frame  runtime.Frame // callsite; PC zero força a descoberta do caller pela pilha
 }
 
 // ====== logInternal SWITCH ======
-func (l *Logger) logInternal(level, msg string,
-	extras []KeyValuePair, ctx context.Context) {
-	if l.jsonMode {
-		l.logInternalJSON(level, msg, extras, ctx)
+
+// logInternal monta o Record, aplica os hooks e a redação e escreve o
+// registro no formato configurado.
+func (l *Logger) logInternal(e entry, ctx context.Context) {
+	cfg := l.config()
+	l.emit(cfg, l.newRecord(cfg, e, ctx), ctx)
+}
+
+// emit aplica hooks e redação ao Record e o escreve.
+func (l *Logger) emit(cfg config, r *Record, ctx context.Context) {
+	if !l.runHooks(ctx, r) {
 		return
 	}
-	now := time.Now()
-
-	var traceID, spanID string
-	if span := trace.SpanFromContext(ctx); span != nil {
-		sc := span.SpanContext()
-		if sc.IsValid() {
-			traceID = sc.TraceID().String()
-			spanID = sc.SpanID().String()
-		}
+	if l.redactor != nil {
+		l.redactor.apply(r)
 	}
-	// Normaliza extras e captura caller preferido (goroutine_caller) se presente
-	caller := ""
-	normalized := make(map[string]string)
-	for _, kv := range extras {
-		v := strings.ReplaceAll(kv.value, "\n", "")
-		v = strings.ReplaceAll(v, "\r", "")
-		v = strings.TrimSpace(v)
-		normalized[kv.key] = v
-	}
-	if v, ok := normalized["goroutine_caller"]; ok {
-		if strings.Contains(v, ":") {
-			parts := strings.Split(v, ":")
-			last := parts[len(parts)-1]
-			path := strings.Join(parts[:len(parts)-1], ":")
-			if _, err := strconv.Atoi(last); err == nil {
-				// file:line
-				caller = filepath.Base(path) + ":" + last
-				normalized["goroutine_caller"] = caller
-			} else {
-				// file:func -> prioriza __callsite (se presente) como linha confiável
-				if cs, okcs := normalized["__callsite"]; okcs && strings.Contains(cs, ":") {
-					partsCs := strings.Split(cs, ":")
-					linePart := partsCs[len(partsCs)-1]
-					caller = filepath.Base(path) + ":" + last + ":" + linePart
-				} else if goLine, found := findGoStmtLineInFunc(path, last); found {
-					caller = filepath.Base(path) + ":" + last + ":" + fmt.Sprintf("%d", goLine)
-				} else if ln, found := findLogCallLineInFunc(path, last); found {
-					// fallback: usa a linha do primeiro log dentro da função
-					caller = filepath.Base(path) + ":" + last + ":" + fmt.Sprintf("%d", ln)
-				} else {
-					// fallback final: usa a linha capturada pelo runtime
-					if gc := l.getCaller(3); strings.Contains(gc, ":") {
-						parts2 := strings.Split(gc, ":")
-						if len(parts2) >= 3 {
-							linePart := parts2[len(parts2)-1]
-							caller = filepath.Base(path) + ":" + last + ":" + linePart
-						} else if len(parts2) >= 2 {
-							linePart := parts2[len(parts2)-1]
-							caller = filepath.Base(path) + ":" + last + ":" + linePart
-						} else {
-							caller = filepath.Base(path) + ":" + last
-						}
-					} else {
-						caller = filepath.Base(path) + ":" + last
-					}
-				}
-				normalized["goroutine_caller"] = caller
-			}
-		} else {
-			normalized["goroutine_caller"] = filepath.Base(v)
-			caller = normalized["goroutine_caller"]
-		}
+	l.recordOnSpan(ctx, cfg, r)
+	if len(l.sinks) > 0 {
+		l.writeSinks(cfg, r, ctx)
+		return
 	}
-	if caller == "" {
-		caller = l.getCaller(3)
+	switch {
+	case cfg.jsonMode:
+		l.write(l.writer, l.encodeJSON(cfg, r))
+	case cfg.logfmt:
+		l.write(l.writer, l.encodeLogfmt(cfg, r))
+	default:
+		l.write(l.writer, l.encodeText(cfg, r))
 	}
+}
 
+// newRecord resolve caller, IDs de trace e campos de um entry.
+func (l *Logger) newRecord(cfg config, e entry, ctx context.Context) *Record {
+	r := &Record{Time: e.time, Level: e.level, Message: e.msg}
+	var spanAttrs []KeyValuePair
+	if span := trace.SpanFromContext(ctx); span != nil {
+		r.setTrace(span)
+		if cfg.includeSpanAttrs {
+			spanAttrs = l.spanAttrFilter.capture(l.spanAttrSource, span.SpanContext())
+		}
+	}
+	// o goroutine_caller, quando presente, é o caller principal; senão o
+	// frame fica no Record e cada encoder o formata no próprio modo
+	r.Caller = l.formatCaller(e.frame, cfg.jsonMode)
+	gc, hasGC := resolveGoroutineCaller(e.extras, e.frame)
+	if hasGC {
+		r.Caller = gc
+	} else {
+		r.frame, r.caller = e.frame, r.Caller
+	}
+	// atributos do span primeiro, depois baggage e campos do contexto e
+	// por fim os extras na ordem da chamada; extras sobrescrevem os
+	// anteriores de mesma chave
+	ctxFields := l.contextFields(ctx)
+	fields := make([]KeyValuePair, 0, len(spanAttrs)+len(ctxFields)+len(e.extras))
+	fields = append(fields, spanAttrs...)
+	fields = append(fields, ctxFields...)
+	for _, kv := range e.extras {
+		if hasGC && kv.key == "goroutine_caller" {
+			kv.value = gc
+		}
+		fields = append(fields, kv)
+	}
+	r.fields = dedupeFields(fields)
+	if l.errorStack && hasErrorField(r.fields) {
+		r.stack = captureStack(e.frame.PC)
+	}
+	return r
+}
+
+// encodeText renderiza o Record com o template configurado.
+func (l *Logger) encodeText(cfg config, r *Record) string {
 	extraStr := ""
-	if len(normalized) > 0 {
-		var parts []string
+	if len(r.fields) > 0 {
+		parts := make([]string, 0, len(r.fields))
 		colorCode := ""
-		if l.color {
-			colorCode = getColorCode(level)
+		if cfg.color {
+			colorCode = cfg.levels.getColorCode(r.Level)
 		}
-		// preserve deterministic order: goroutine_caller first if present
-		if v, ok := normalized["goroutine_caller"]; ok {
-			keyColored := "goroutine_caller"
+		colorize := func(key string) string {
 			if colorCode != "" {
-				keyColored = colorCode + keyColored + colorReset
+				return colorCode + key + colorReset
 			}
-			parts = append(parts, fmt.Sprintf("%s=%s", keyColored, v))
+			return key
 		}
-		for k, v := range normalized {
-			if k == "goroutine_caller" || k == "__callsite" {
-				continue
+		pair := func(key string, value any) string {
+			return colorize(key) + "=" + normalizeText(formatValue(value))
+		}
+		appendPart := func(kv KeyValuePair) {
+			if info, ok := asErrorInfo(kv.value, r.stack, false); ok {
+				parts = append(parts, info.textParts(kv.key, pair)...)
+				return
 			}
-			keyColored := k
-			if colorCode != "" {
-				keyColored = colorCode + k + colorReset
+			parts = append(parts, pair(kv.key, kv.value))
+		}
+		// ordem determinística: goroutine_caller primeiro, depois os
+		// demais campos na ordem do Record
+		for _, kv := range r.fields {
+			if kv.key == "goroutine_caller" {
+				appendPart(kv)
+			}
+		}
+		for _, kv := range r.fields {
+			if kv.key != "goroutine_caller" {
+				appendPart(kv)
 			}
-			parts = append(parts, fmt.Sprintf("%s=%s", keyColored, v))
 		}
 		extraStr = strings.Join(parts, " ")
 	}
-	output := l.formatMessage(level, msg, extraStr, now, traceID, spanID, caller)
-	fmt.Fprintln(l.writer, output)
-}
+	return l.formatMessage(cfg, r, extraStr)
+}
+
+// With retorna um logger derivado que anexa os pares chave/valor kv a todos
+// os registros, antes dos extras de cada chamada. O logger derivado
+// compartilha o writer com o original e parte de uma cópia da sua
+// configuração (incluindo o nível mínimo); alterações posteriores em um
+// não afetam o outro.
+func (l *Logger) With(kv ...any) *Logger {
+	c := l.clone()
+	if extra := parseKeyValues(kv...); len(extra) > 0 {
+		fields := make([]KeyValuePair, 0, len(c.fields)+len(extra))
+		fields = append(fields, c.fields...)
+		c.fields = append(fields, extra...)
+	}
+	return c
+}
+
+// clone cria um novo Logger com a mesma configuração e o mesmo destino de
+// escrita.
+func (l *Logger) clone() *Logger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	c := &Logger{
+		writeMu:          l.writeMu,
+		writer:           l.writer,
+		format:           l.format,
+		appName:          l.appName,
+		color:            l.color,
+		jsonMode:         l.jsonMode,
+		logfmt:           l.logfmt,
+		includeSpanAttrs: l.includeSpanAttrs,
+		fields:           l.fields,
+		callerFormat:     l.callerFormat,
+		callerSkip:       l.callerSkip,
+		timeFormat:       l.timeFormat,
+		utc:              l.utc,
+		clock:            l.clock,
+		exitFn:           l.exitFn,
+		levels:           l.levels,
+		async:            l.async,
+		sampler:          l.sampler,
+		summaryInterval:  l.summaryInterval,
+		summary:          l.summary,
+		hooks:            l.hooks,
+		redactor:         l.redactor,
+		errorStack:       l.errorStack,
+		sinks:            l.sinks,
+		spanOpts:         l.spanOpts,
+		baggageKeys:      l.baggageKeys,
+		traceDetails:     l.traceDetails,
+		spanAttrFilter:   l.spanAttrFilter,
+		spanAttrSource:   l.spanAttrSource,
+	}
+	c.level.Store(l.level.Load())
+	return c
+}
+
+// Setters de configuração; seguros para uso concorrente com as chamadas de log.
 
 func (l *Logger) SetAppName(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.appName = name
 }
 
 func (l *Logger) SetColor(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.color = enabled
 }
 
 func (l *Logger) SetJSON(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.jsonMode = enabled
 }
 
 func (l *Logger) SetIncludeSpanAttrs(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.includeSpanAttrs = enabled
 }
 
@@ -709,83 +660,165 @@ func (l *Logger) SetIncludeSpanAttrs(enabled bool) {
 
 // Métodos de log com formatação estilo fmt.Sprintf
 func (l *Logger) Infof(format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("INFO", []any{msg}, context.Background())
+	l.logf(context.Background(), LevelInfo, format, args...)
 }
 func (l *Logger) Warnf(format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("WARN", []any{msg}, context.Background())
+	l.logf(context.Background(), LevelWarn, format, args...)
 }
 func (l *Logger) Errorf(format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("ERROR", []any{msg}, context.Background())
+	l.logf(context.Background(), LevelError, format, args...)
 }
 func (l *Logger) Debugf(format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("DEBUG", []any{msg}, context.Background())
+	l.logf(context.Background(), LevelDebug, format, args...)
 }
 
-func (l *Logger) Info(args ...any)  { l.logWithArgs("INFO", args, context.Background()) }
-func (l *Logger) Warn(args ...any)  { l.logWithArgs("WARN", args, context.Background()) }
-func (l *Logger) Error(args ...any) { l.logWithArgs("ERROR", args, context.Background()) }
-func (l *Logger) Debug(args ...any) { l.logWithArgs("DEBUG", args, context.Background()) }
+// Métodos que interrompem o fluxo: Panic* registram e chamam panic com a
+// mensagem; Fatal* registram e encerram o processo com código 1. Em ambos os
+// casos o writer é descarregado (Sync) antes.
+func (l *Logger) Panicf(format string, args ...any) {
+	l.logf(context.Background(), LevelPanic, format, args...)
+}
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.logf(context.Background(), LevelFatal, format, args...)
+}
+func (l *Logger) Panic(args ...any) { l.logWithArgs(LevelPanic, args, context.Background()) }
+func (l *Logger) Fatal(args ...any) { l.logWithArgs(LevelFatal, args, context.Background()) }
+func (l *Logger) PanicCtx(ctx context.Context, args ...any) {
+	l.logWithArgs(LevelPanic, args, ctx)
+}
+func (l *Logger) FatalCtx(ctx context.Context, args ...any) {
+	l.logWithArgs(LevelFatal, args, ctx)
+}
+func (l *Logger) PanicCtxf(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, LevelPanic, format, args...)
+}
+func (l *Logger) FatalCtxf(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, LevelFatal, format, args...)
+}
+
+// Métodos genéricos para qualquer nível, inclusive os registrados com
+// WithCustomLevel/RegisterLevel.
+func (l *Logger) Log(level Level, args ...any) { l.logWithArgs(level, args, context.Background()) }
+func (l *Logger) LogCtx(ctx context.Context, level Level, args ...any) {
+	l.logWithArgs(level, args, ctx)
+}
+func (l *Logger) Logf(level Level, format string, args ...any) {
+	l.logf(context.Background(), level, format, args...)
+}
+func (l *Logger) LogCtxf(ctx context.Context, level Level, format string, args ...any) {
+	l.logf(ctx, level, format, args...)
+}
+
+func (l *Logger) Tracef(format string, args ...any) {
+	l.logf(context.Background(), LevelTrace, format, args...)
+}
+func (l *Logger) Trace(args ...any) { l.logWithArgs(LevelTrace, args, context.Background()) }
+func (l *Logger) TraceCtx(ctx context.Context, args ...any) {
+	l.logWithArgs(LevelTrace, args, ctx)
+}
+func (l *Logger) TraceCtxf(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, LevelTrace, format, args...)
+}
+
+func (l *Logger) Info(args ...any)  { l.logWithArgs(LevelInfo, args, context.Background()) }
+func (l *Logger) Warn(args ...any)  { l.logWithArgs(LevelWarn, args, context.Background()) }
+func (l *Logger) Error(args ...any) { l.logWithArgs(LevelError, args, context.Background()) }
+func (l *Logger) Debug(args ...any) { l.logWithArgs(LevelDebug, args, context.Background()) }
 
 // Métodos de log com contexto.
-func (l *Logger) InfoCtx(ctx context.Context, args ...any)  { l.logWithArgs("INFO", args, ctx) }
-func (l *Logger) WarnCtx(ctx context.Context, args ...any)  { l.logWithArgs("WARN", args, ctx) }
-func (l *Logger) ErrorCtx(ctx context.Context, args ...any) { l.logWithArgs("ERROR", args, ctx) }
-func (l *Logger) DebugCtx(ctx context.Context, args ...any) { l.logWithArgs("DEBUG", args, ctx) }
+func (l *Logger) InfoCtx(ctx context.Context, args ...any)  { l.logWithArgs(LevelInfo, args, ctx) }
+func (l *Logger) WarnCtx(ctx context.Context, args ...any)  { l.logWithArgs(LevelWarn, args, ctx) }
+func (l *Logger) ErrorCtx(ctx context.Context, args ...any) { l.logWithArgs(LevelError, args, ctx) }
+func (l *Logger) DebugCtx(ctx context.Context, args ...any) { l.logWithArgs(LevelDebug, args, ctx) }
 
 func (l *Logger) InfoCtxf(ctx context.Context, format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("INFO", []any{msg}, ctx)
+	l.logf(ctx, LevelInfo, format, args...)
 }
 func (l *Logger) WarnCtxf(ctx context.Context, format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
-	}
-	l.logWithArgs("WARN", []any{msg}, ctx)
+	l.logf(ctx, LevelWarn, format, args...)
 }
 func (l *Logger) ErrorCtxf(ctx context.Context, format string, args ...any) {
-	if strings.Contains(format, "%w") {
-		msg := fmt.Errorf(format, args...).Error()
-		l.logWithArgs("ERROR", []any{msg}, ctx)
+	l.logf(ctx, LevelError, format, args...)
+}
+func (l *Logger) DebugCtxf(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, LevelDebug, format, args...)
+}
+
+// logWithArgs e logf descartam o registro antes de qualquer formatação
+// quando o nível está desabilitado ou o sampler o suprime.
+func (l *Logger) logWithArgs(level Level, args []any, ctx context.Context) {
+	if !l.Enabled(level) {
 		return
 	}
-	msg := fmt.Sprintf(format, args...)
-	l.logWithArgs("ERROR", []any{msg}, ctx)
+	suppressed, ok := l.sample(level, messageKey(args))
+	if !ok {
+		return
+	}
+	l.log(level, args, ctx, 3, suppressed)
 }
-func (l *Logger) DebugCtxf(ctx context.Context, format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	if strings.Contains(format, "%w") {
-		msg = fmt.Errorf(format, args...).Error()
+
+func (l *Logger) logf(ctx context.Context, level Level, format string, args ...any) {
+	if !l.Enabled(level) {
+		return
+	}
+	suppressed, ok := l.sample(level, format)
+	if !ok {
+		return
 	}
-	l.logWithArgs("DEBUG", []any{msg}, ctx)
+	l.log(level, errorfArgs(format, args...), ctx, 3, suppressed)
 }
 
-func (l *Logger) logWithArgs(level string, args []any, ctx context.Context) {
+// log processa os argumentos e registra o callsite; skip indica quantos
+// frames acima de log está o código do usuário (somado a WithCallerSkip).
+// suppressed > 0 emite antes o resumo dos registros descartados pelo
+// sampler.
+func (l *Logger) log(level Level, args []any, ctx context.Context, skip int, suppressed uint64) {
 	msg, extras := parseLogArgs(args...)
-	// captura o callsite onde logWithArgs foi chamado para usar como fallback
-	if _, file, line, ok := runtime.Caller(2); ok {
-		extras = append(extras, KeyValuePair{"__callsite", fmt.Sprintf("%s:%d", file, line)})
+	if len(l.fields) > 0 {
+		merged := make([]KeyValuePair, 0, len(l.fields)+len(extras))
+		merged = append(merged, l.fields...)
+		extras = append(merged, extras...)
+	}
+	e := entry{
+		time:   l.now(),
+		level:  level,
+		msg:    msg,
+		extras: extras,
+		frame:  callerFrame(skip + l.callerSkip),
+	}
+	if suppressed > 0 {
+		l.logInternal(summaryEntry(e, l.fields, suppressed), ctx)
+	}
+	l.logInternal(e, ctx)
+	l.terminate(level, msg)
+}
+
+// WithExitFunc substitui a função chamada por Fatal* após registrar a
+// mensagem (padrão os.Exit), útil em testes e para encerramentos
+// controlados.
+func WithExitFunc(fn func(code int)) Option {
+	return func(l *Logger) {
+		if fn != nil {
+			l.exitFn = fn
+		}
+	}
+}
+
+// terminate aplica a semântica de PANIC e FATAL depois que o registro foi
+// escrito, descarregando o writer antes de interromper o programa. O valor
+// do panic passa pela redação, como a linha registrada.
+func (l *Logger) terminate(level Level, msg string) {
+	switch level {
+	case LevelPanic:
+		_ = l.Flush()
+		if l.redactor != nil {
+			msg = l.redactor.redactString(msg)
+		}
+		panic(msg)
+	case LevelFatal:
+		_ = l.Flush()
+		l.exitFn(1)
 	}
-	l.logInternal(level, msg, extras, ctx)
 }
 
 // GoroutineLogger é um wrapper de Logger usado dentro de uma goroutine
@@ -793,95 +826,88 @@ func (l *Logger) logWithArgs(level string, args []any, ctx context.Context) {
 // de criação (quando WrapGoroutine() foi chamado).
 type GoroutineLogger struct {
 	parent          *Logger
-	goroutineCaller string
+	goroutineCaller callerString
 }
 
 // WrapGoroutine captura o callsite do ponto onde é invocado e retorna um
 // wrapper que, quando usado dentro da goroutine, adiciona automaticamente
-// o extra "goroutine_caller" às chamadas de log.
+// o extra "goroutine_caller" às chamadas de log. O callsite vem do
+// runtime (basename:função:linha, ou o formato de WithCallerFlag/
+// WithCallerFormatter) e não depende do código-fonte estar disponível.
 func (l *Logger) WrapGoroutine() *GoroutineLogger {
-	// pc=0: runtime.Caller(0) returns inside this function; we want caller of WrapGoroutine
-	pc, file, line, ok := runtime.Caller(1)
-	var callerVal string
-	if ok {
-		fn := ""
-		if f := runtime.FuncForPC(pc); f != nil {
-			full := f.Name()
-			parts := strings.Split(full, ".")
-			fn = parts[len(parts)-1]
-		}
-		// tenta localizar a linha exata do 'go' dentro da função do chamador
-		if fn != "" {
-			if goLine, found := findGoStmtLineInFunc(file, fn); found {
-				// ajusta um pequeno offset para alinhar com a contagem de linhas esperada
-				goLine += 2
-				callerVal = fmt.Sprintf("%s:%s:%d", filepath.Base(file), fn, goLine)
-			} else {
-				// fallback: procura o 'go' statement no arquivo nas linhas próximas ao caller
-				if data, err := os.ReadFile(file); err == nil {
-					lines := strings.Split(string(data), "\n")
-					start := line
-					if start < 1 {
-						start = 1
-					}
-					end := start + 20
-					if end > len(lines) {
-						end = len(lines)
-					}
-					foundLine := 0
-					for i := start; i <= end; i++ {
-						ln := lines[i-1]
-						if strings.Contains(ln, "go ") || strings.Contains(ln, "go(") {
-							foundLine = i
-							break
-						}
-					}
-					if foundLine != 0 {
-						callerVal = fmt.Sprintf("%s:%s:%d", filepath.Base(file), fn, foundLine)
-					} else {
-						callerVal = fmt.Sprintf("%s:%s:%d", filepath.Base(file), fn, line)
-					}
-				} else {
-					callerVal = fmt.Sprintf("%s:%s:%d", filepath.Base(file), fn, line)
-				}
-			}
-		} else {
-			callerVal = fmt.Sprintf("%s:%d", filepath.Base(file), line)
-		}
+	var callerVal callerString
+	if fr := callerFrame(1); fr.PC != 0 {
+		callerVal = callerString(l.formatCaller(fr, false))
 	}
 	return &GoroutineLogger{parent: l, goroutineCaller: callerVal}
 }
 
-// Métodos que espelham a API do Logger, anexando goroutine_caller.
-func (g *GoroutineLogger) Info(args ...any)  { g.callWithExtra("INFO", args...) }
-func (g *GoroutineLogger) Warn(args ...any)  { g.callWithExtra("WARN", args...) }
-func (g *GoroutineLogger) Error(args ...any) { g.callWithExtra("ERROR", args...) }
-func (g *GoroutineLogger) Debug(args ...any) { g.callWithExtra("DEBUG", args...) }
+// With retorna um GoroutineLogger derivado com os campos kv anexados,
+// preservando o goroutine_caller capturado.
+func (g *GoroutineLogger) With(kv ...any) *GoroutineLogger {
+	return &GoroutineLogger{parent: g.parent.With(kv...), goroutineCaller: g.goroutineCaller}
+}
 
+// Métodos que espelham a API do Logger, anexando goroutine_caller.
+func (g *GoroutineLogger) Log(level Level, args ...any) { g.callWithExtra(level, args...) }
+func (g *GoroutineLogger) Trace(args ...any)            { g.callWithExtra(LevelTrace, args...) }
+func (g *GoroutineLogger) Info(args ...any)             { g.callWithExtra(LevelInfo, args...) }
+func (g *GoroutineLogger) Warn(args ...any)             { g.callWithExtra(LevelWarn, args...) }
+func (g *GoroutineLogger) Error(args ...any)            { g.callWithExtra(LevelError, args...) }
+func (g *GoroutineLogger) Debug(args ...any)            { g.callWithExtra(LevelDebug, args...) }
+func (g *GoroutineLogger) Panic(args ...any)            { g.callWithExtra(LevelPanic, args...) }
+func (g *GoroutineLogger) Fatal(args ...any)            { g.callWithExtra(LevelFatal, args...) }
+
+func (g *GoroutineLogger) Tracef(format string, args ...any) {
+	g.callfWithExtra(LevelTrace, format, args...)
+}
 func (g *GoroutineLogger) Infof(format string, args ...any) {
-	g.callfWithExtra("INFO", format, args...)
+	g.callfWithExtra(LevelInfo, format, args...)
 }
 func (g *GoroutineLogger) Warnf(format string, args ...any) {
-	g.callfWithExtra("WARN", format, args...)
+	g.callfWithExtra(LevelWarn, format, args...)
 }
 func (g *GoroutineLogger) Errorf(format string, args ...any) {
-	g.callfWithExtra("ERROR", format, args...)
+	g.callfWithExtra(LevelError, format, args...)
 }
 func (g *GoroutineLogger) Debugf(format string, args ...any) {
-	g.callfWithExtra("DEBUG", format, args...)
+	g.callfWithExtra(LevelDebug, format, args...)
+}
+func (g *GoroutineLogger) Panicf(format string, args ...any) {
+	g.callfWithExtra(LevelPanic, format, args...)
+}
+func (g *GoroutineLogger) Fatalf(format string, args ...any) {
+	g.callfWithExtra(LevelFatal, format, args...)
 }
 
 // Helpers internos para anexar o par chave/valor goroutine_caller.
-func (g *GoroutineLogger) callWithExtra(level string, args ...any) {
+func (g *GoroutineLogger) callWithExtra(level Level, args ...any) {
+	if !g.parent.Enabled(level) {
+		return
+	}
+	suppressed, ok := g.parent.sample(level, messageKey(args))
+	if !ok {
+		return
+	}
+	g.parent.log(level, g.withCaller(args), context.Background(), 3, suppressed)
+}
+
+func (g *GoroutineLogger) callfWithExtra(level Level, format string, args ...any) {
+	if !g.parent.Enabled(level) {
+		return
+	}
+	suppressed, ok := g.parent.sample(level, format)
+	if !ok {
+		return
+	}
+	g.parent.log(level, g.withCaller(errorfArgs(format, args...)), context.Background(), 3, suppressed)
+}
+
+func (g *GoroutineLogger) withCaller(args []any) []any {
 	newArgs := make([]any, 0, len(args)+2)
 	newArgs = append(newArgs, args...)
 	if g.goroutineCaller != "" {
 		newArgs = append(newArgs, "goroutine_caller", g.goroutineCaller)
 	}
-	g.parent.logWithArgs(level, newArgs, context.Background())
-}
-
-func (g *GoroutineLogger) callfWithExtra(level, format string, args ...any) {
-	msg := fmt.Sprintf(format, args...)
-	g.callWithExtra(level, msg)
+	return newArgs
 }
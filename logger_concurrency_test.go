@@ -0,0 +1,111 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// unsafeWriter é um writer propositalmente não seguro para concorrência que
+// detecta chamadas Write sobrepostas.
+type unsafeWriter struct {
+	buf      bytes.Buffer
+	inWrite  atomic.Bool
+	overlaps atomic.Int64
+}
+
+func (w *unsafeWriter) Write(p []byte) (int, error) {
+	if !w.inWrite.CompareAndSwap(false, true) {
+		w.overlaps.Add(1)
+	}
+	defer w.inWrite.Store(false)
+	return w.buf.Write(p)
+}
+
+func TestLogger_ConcurrentWritesAreWholeLines(t *testing.T) {
+	w := &unsafeWriter{}
+	l := NewLogger(WithWriter(w), WithJSON(true))
+
+	const goroutines = 300
+	const perGoroutine = 20
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				l.Info("concurrent message", "g", i, "j", j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := w.overlaps.Load(); n != 0 {
+		t.Fatalf("detectadas %d escritas sobrepostas no writer", n)
+	}
+	lines := strings.Split(strings.TrimRight(w.buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*perGoroutine {
+		t.Fatalf("esperado %d linhas, obteve %d", goroutines*perGoroutine, len(lines))
+	}
+	for _, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("linha JSON corrompida: %v\nlinha: %q", err, line)
+		}
+	}
+}
+
+func TestLogger_ConcurrentSettersAndLogging(t *testing.T) {
+	w := &unsafeWriter{}
+	l := NewLogger(WithWriter(w), WithAppName("Race"))
+
+	const goroutines = 200
+	var wg sync.WaitGroup
+	wg.Add(goroutines * 2)
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			switch i % 5 {
+			case 0:
+				l.SetAppName("Race")
+			case 1:
+				l.SetColor(i%2 == 0)
+			case 2:
+				l.SetJSON(i%3 == 0)
+			case 3:
+				l.SetIncludeSpanAttrs(i%2 == 0)
+			case 4:
+				l.SetLevel(LevelDebug)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			l.Info("hammer", "i", i)
+			l.Warnf("hammer %d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	if n := w.overlaps.Load(); n != 0 {
+		t.Fatalf("detectadas %d escritas sobrepostas no writer", n)
+	}
+	lines := strings.Split(strings.TrimRight(w.buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*2 {
+		t.Fatalf("esperado %d linhas, obteve %d", goroutines*2, len(lines))
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "{") {
+			var rec map[string]any
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				t.Fatalf("linha JSON corrompida: %v\nlinha: %q", err, line)
+			}
+			continue
+		}
+		if !strings.Contains(line, "[Race]") || !strings.Contains(line, "hammer") {
+			t.Fatalf("linha de texto inesperada: %q", line)
+		}
+	}
+}
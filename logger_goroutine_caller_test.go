@@ -3,6 +3,10 @@ package wslogger
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -76,3 +80,63 @@ func TestLogger_GoroutineFlow(t *testing.T) {
 		}
 	}
 }
+
+// WrapGoroutine deve reportar a linha onde foi chamado usando apenas o
+// runtime, mesmo sem acesso ao código-fonte (diretório de trabalho vazio).
+func TestLogger_GoroutineCallerWithoutSource(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	var buf bytes.Buffer
+	log := NewLogger(WithWriter(&buf), WithJSON(true))
+
+	g := log.WrapGoroutine()
+	_, _, line, _ := runtime.Caller(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Info("inside goroutine")
+	}()
+	<-done
+
+	var r struct {
+		Caller string         `json:"caller"`
+		Extra  map[string]any `json:"extra"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf("invalid json log line: %v, line=%s", err, buf.String())
+	}
+	want := fmt.Sprintf("logger_goroutine_caller_test.go:TestLogger_GoroutineCallerWithoutSource:%d", line-1)
+	if r.Caller != want {
+		t.Errorf("caller = %q, esperado %q", r.Caller, want)
+	}
+	if r.Extra["goroutine_caller"] != want {
+		t.Errorf("goroutine_caller = %v, esperado %q", r.Extra["goroutine_caller"], want)
+	}
+}
+
+// Um goroutine_caller informado manualmente é reduzido ao basename e,
+// quando só traz a função, completado com a linha do callsite.
+func TestLogger_ManualGoroutineCaller(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(WithWriter(&buf), WithFormat("{caller} {extra}"), WithColor(false))
+
+	log.Info("manual", "goroutine_caller", "/src/app/main.go:42")
+	if got := buf.String(); !strings.HasPrefix(got, "main.go:42 goroutine_caller=main.go:42") {
+		t.Errorf("file:line inesperado: %q", got)
+	}
+	buf.Reset()
+
+	log.Info("manual", "goroutine_caller", "/src/app/main.go:main")
+	_, _, line, _ := runtime.Caller(0)
+	want := fmt.Sprintf("main.go:main:%d", line-1)
+	if got := buf.String(); !strings.HasPrefix(got, want+" goroutine_caller="+want) {
+		t.Errorf("file:func inesperado: %q, esperado prefixo %q", got, want)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/natefinch/lumberjack"
+	"github.com/thiagozs/go-wslogger/otelsdk"
 	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
@@ -304,14 +305,16 @@ func TestLogger_JSONOutput(t *testing.T) {
 
 func TestLogger_JSON_WithSpanAttributes(t *testing.T) {
 	var buf strings.Builder
+	src := otelsdk.NewSpanAttributeProcessor()
 	logger := NewLogger(
 		WithWriter(&buf),
 		WithJSON(true),
 		WithSpanAttributes(true),
+		WithSpanAttributeSource(src),
 	)
 
 	// Cria contexto com span e atributos
-	tp := sdktrace.NewTracerProvider()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(src))
 	tracer := tp.Tracer("logger-test")
 	ctx, span := tracer.Start(context.Background(), "test-span")
 	span.SetAttributes(
@@ -461,3 +464,183 @@ func TestLogger_MultiWriter(t *testing.T) {
 		t.Error("Arquivo de log não contém a mensagem esperada")
 	}
 }
+
+func TestLogger_With(t *testing.T) {
+	var buf strings.Builder
+	base := NewLogger(
+		WithWriter(&buf),
+		WithFormat("{level} {message} {extra}"),
+		WithColor(false),
+	)
+	child := base.With("request_id", "abc-123", "tenant", "acme")
+	grandchild := child.With("component", "billing")
+
+	grandchild.Info("charged", "amount", 10)
+	out := buf.String()
+	for _, want := range []string{"request_id=abc-123", "tenant=acme", "component=billing", "amount=10"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída deveria conter %q: %q", want, out)
+		}
+	}
+	buf.Reset()
+
+	// O logger original não recebe os campos do derivado.
+	base.Info("plain")
+	if strings.Contains(buf.String(), "request_id") {
+		t.Errorf("campos do logger derivado vazaram para o original: %q", buf.String())
+	}
+	buf.Reset()
+
+	// Alterações no derivado não afetam o original.
+	child.SetLevel(LevelError)
+	base.Info("base ainda emite")
+	child.Info("child descartado")
+	if !strings.Contains(buf.String(), "base ainda emite") || strings.Contains(buf.String(), "child descartado") {
+		t.Errorf("nível do derivado deveria ser independente: %q", buf.String())
+	}
+}
+
+func TestLogger_WithJSON(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithJSON(true)).With("request_id", "abc-123", "tenant", "acme")
+
+	l.Info("json with fields", "tenant", "override", "foo", "bar")
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+	}
+	extra, ok := record["extra"].(map[string]any)
+	if !ok {
+		t.Fatalf("missing or invalid extra: %v", record["extra"])
+	}
+	if extra["request_id"] != "abc-123" || extra["foo"] != "bar" {
+		t.Errorf("extra deveria conter campos fixos e da chamada: %v", extra)
+	}
+	if extra["tenant"] != "override" {
+		t.Errorf("extra da chamada deveria sobrescrever campo fixo: %v", extra)
+	}
+}
+
+func TestGoroutineLogger_With(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithJSON(true))
+	g := l.WrapGoroutine().With("worker", "w7")
+
+	g.Info("from worker")
+
+	var record struct {
+		Extra map[string]string `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+	}
+	if record.Extra["worker"] != "w7" {
+		t.Errorf("extra deveria conter worker=w7: %v", record.Extra)
+	}
+	if record.Extra["goroutine_caller"] == "" {
+		t.Errorf("goroutine_caller deveria ser preservado: %v", record.Extra)
+	}
+}
+
+type jsonPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type upperMarshaler string
+
+func (u upperMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(string(u)))
+}
+
+func TestLogger_JSONTypedExtras(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithJSON(true))
+
+	l.Info("typed",
+		"count", 42,
+		"ratio", 0.5,
+		"ok", true,
+		"nothing", nil,
+		"tags", []string{"a", "b"},
+		"meta", map[string]int{"n": 1},
+		"point", jsonPoint{X: 1, Y: 2},
+		"custom", upperMarshaler("abc"),
+		"err", fmt.Errorf("boom"),
+		"text", "hello world",
+		"fn", func() {},
+	)
+
+	var record struct {
+		Extra map[string]json.RawMessage `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+	}
+	want := map[string]string{
+		"count":   `42`,
+		"ratio":   `0.5`,
+		"ok":      `true`,
+		"nothing": `null`,
+		"tags":    `["a","b"]`,
+		"meta":    `{"n":1}`,
+		"point":   `{"x":1,"y":2}`,
+		"custom":  `"ABC"`,
+		"err":     `{"message":"boom","type":"*errors.errorString"}`,
+		"text":    `"hello world"`,
+	}
+	for k, v := range want {
+		if got := string(record.Extra[k]); got != v {
+			t.Errorf("extra[%q] = %s, esperado %s", k, got, v)
+		}
+	}
+	if fn := string(record.Extra["fn"]); !strings.HasPrefix(fn, `"0x`) {
+		t.Errorf("valor não serializável deveria cair para texto, obteve %s", fn)
+	}
+}
+
+func TestLogger_TextTypedExtrasUnchanged(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithColor(false))
+
+	l.Info("typed", "count", 42, "tags", []string{"a", "b"}, "text", "hello world")
+	out := buf.String()
+	for _, want := range []string{"count=42", "tags=[a b]", `text="hello world"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída deveria conter %q: %q", want, out)
+		}
+	}
+}
+
+func TestLogger_ExtrasOrderText(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(
+		WithWriter(&buf),
+		WithFormat("{message} {extra}"),
+		WithColor(false),
+	).With("request_id", "r1", "tenant", "acme")
+
+	for i := 0; i < 20; i++ {
+		buf.Reset()
+		l.Info("ordered", "zeta", 1, "alpha", 2, "tenant", "override", "mid", 3)
+		want := "ordered request_id=r1 tenant=override zeta=1 alpha=2 mid=3"
+		if got := strings.TrimSpace(buf.String()); got != want {
+			t.Fatalf("ordem dos extras inesperada:\nobteve:   %q\nesperado: %q", got, want)
+		}
+	}
+}
+
+func TestLogger_ExtrasOrderJSON(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithJSON(true)).With("request_id", "r1")
+
+	for i := 0; i < 20; i++ {
+		buf.Reset()
+		l.Info("ordered", "zeta", 1, "alpha", true, "mid", "x")
+		want := `"extra":{"request_id":"r1","zeta":1,"alpha":true,"mid":"x"}`
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("ordem dos extras inesperada: %q", buf.String())
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelScope é o nome do instrumentation scope dos LogRecords emitidos.
+const otelScope = "github.com/thiagozs/go-wslogger"
+
+// otelSeverity converte o nível na severidade OTel. Os níveis padrão têm
+// o mesmo espaçamento da escala OTel (TRACE=1, DEBUG=5, INFO=9, WARN=13,
+// ERROR=17, FATAL=21), então lv+9 cai no início de cada faixa e níveis
+// customizados intermediários caem nos subníveis (ex.: Level(6) é WARN3).
+// FATAL vira FATAL4 e valores fora da escala são limitados às pontas.
+func otelSeverity(lv Level) otellog.Severity {
+	s := int(lv) + 9
+	switch {
+	case s < int(otellog.SeverityTrace1):
+		return otellog.SeverityTrace1
+	case s > int(otellog.SeverityFatal4):
+		return otellog.SeverityFatal4
+	}
+	return otellog.Severity(s)
+}
+
+// emitOTel converte o Record num log.Record do OpenTelemetry e o emite
+// pelo logger do sink. O trace context vem de ctx ou, na ausência de um
+// span válido, dos IDs já presentes no Record (ex.: LogRecord).
+func emitOTel(ctx context.Context, logger otellog.Logger, cfg config, r *Record) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	severity := otelSeverity(r.Level)
+	if !logger.Enabled(ctx, otellog.EnabledParameters{Severity: severity}) {
+		return
+	}
+	if !trace.SpanContextFromContext(ctx).IsValid() && r.TraceID != "" {
+		traceID, errT := trace.TraceIDFromHex(r.TraceID)
+		spanID, errS := trace.SpanIDFromHex(r.SpanID)
+		if errT == nil && errS == nil {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID, SpanID: spanID, TraceFlags: r.traceFlags(),
+			}))
+		}
+	}
+
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetSeverity(severity)
+	rec.SetSeverityText(cfg.levels.name(r.Level))
+	rec.SetBody(otellog.StringValue(r.Message))
+	attrs := make([]otellog.KeyValue, 0, len(r.fields))
+	for _, kv := range r.fields {
+		attrs = append(attrs, otellog.KeyValue{Key: kv.key, Value: otelValue(kv.value, r)})
+	}
+	rec.AddAttributes(attrs...)
+	logger.Emit(ctx, rec)
+}
+
+// typedValue normaliza um extra para os tipos que o OTel representa
+// nativamente e é a conversão comum dos LogRecords (otelValue) e dos
+// atributos de span (spanEventAttrs). O resultado é nil, string, bool,
+// int64, float64, []byte, []string, []bool, []int64, []float64,
+// *errorInfo para erros ou json.RawMessage para os demais valores.
+// Inteiros sem sinal acima de MaxInt64 e time.Duration viram string, e
+// valores que não serializam em JSON, sua forma de texto.
+func typedValue(v any, r *Record, jsonMode bool) any {
+	switch val := v.(type) {
+	case nil, string, bool, int64, float64, []byte, []string, []bool, []int64, []float64:
+		return val
+	case int:
+		return int64(val)
+	case int8:
+		return int64(val)
+	case int16:
+		return int64(val)
+	case int32:
+		return int64(val)
+	case uint8:
+		return int64(val)
+	case uint16:
+		return int64(val)
+	case uint32:
+		return int64(val)
+	case uint:
+		return typedUint(uint64(val))
+	case uint64:
+		return typedUint(val)
+	case float32:
+		return float64(val)
+	case []int:
+		ints := make([]int64, len(val))
+		for i, n := range val {
+			ints[i] = int64(n)
+		}
+		return ints
+	case time.Duration:
+		return val.String()
+	}
+	if info, ok := asErrorInfo(v, r.stack, jsonMode); ok {
+		return info
+	}
+	if raw, ok := jsonValue(v).(json.RawMessage); ok {
+		return raw
+	}
+	return formatValue(v)
+}
+
+// typedUint representa como string os uint64 que não cabem em int64.
+func typedUint(u uint64) any {
+	if u > math.MaxInt64 {
+		return strconv.FormatUint(u, 10)
+	}
+	return int64(u)
+}
+
+// otelValue converte um extra num log.Value preservando o tipo (ver
+// typedValue): erros viram um mapa como no JSON (message, type, chain,
+// stack) e os demais valores seguem a serialização JSON, com objetos como
+// mapas e listas como slices.
+func otelValue(v any, r *Record) otellog.Value {
+	switch val := typedValue(v, r, true).(type) {
+	case string:
+		return otellog.StringValue(val)
+	case bool:
+		return otellog.BoolValue(val)
+	case int64:
+		return otellog.Int64Value(val)
+	case float64:
+		return otellog.Float64Value(val)
+	case []byte:
+		return otellog.BytesValue(val)
+	case []string:
+		return otelSlice(val, otellog.StringValue)
+	case []bool:
+		return otelSlice(val, otellog.BoolValue)
+	case []int64:
+		return otelSlice(val, otellog.Int64Value)
+	case []float64:
+		return otelSlice(val, otellog.Float64Value)
+	case *errorInfo:
+		return otelErrorValue(val)
+	case json.RawMessage:
+		dec := json.NewDecoder(bytes.NewReader(val))
+		dec.UseNumber()
+		var decoded any
+		if err := dec.Decode(&decoded); err != nil {
+			return otellog.StringValue(string(val))
+		}
+		return otelJSONValue(decoded)
+	}
+	return otellog.Value{}
+}
+
+func otelSlice[T any](vs []T, conv func(T) otellog.Value) otellog.Value {
+	out := make([]otellog.Value, len(vs))
+	for i, v := range vs {
+		out[i] = conv(v)
+	}
+	return otellog.SliceValue(out...)
+}
+
+func otelErrorValue(info *errorInfo) otellog.Value {
+	kvs := []otellog.KeyValue{
+		otellog.String("message", info.Message),
+		otellog.String("type", info.Type),
+	}
+	if len(info.Chain) > 0 {
+		chain := make([]otellog.Value, len(info.Chain))
+		for i, c := range info.Chain {
+			chain[i] = otellog.MapValue(otellog.String("message", c.Message), otellog.String("type", c.Type))
+		}
+		kvs = append(kvs, otellog.Slice("chain", chain...))
+	}
+	if len(info.Stack) > 0 {
+		stack := make([]otellog.Value, len(info.Stack))
+		for i, s := range info.Stack {
+			stack[i] = otellog.StringValue(s)
+		}
+		kvs = append(kvs, otellog.Slice("stack", stack...))
+	}
+	return otellog.MapValue(kvs...)
+}
+
+// otelJSONValue converte o resultado de json.Decoder (com UseNumber).
+func otelJSONValue(v any) otellog.Value {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return otellog.Int64Value(i)
+		}
+		f, _ := val.Float64()
+		return otellog.Float64Value(f)
+	case []any:
+		vs := make([]otellog.Value, len(val))
+		for i, e := range val {
+			vs[i] = otelJSONValue(e)
+		}
+		return otellog.SliceValue(vs...)
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		kvs := make([]otellog.KeyValue, len(keys))
+		for i, k := range keys {
+			kvs[i] = otellog.KeyValue{Key: k, Value: otelJSONValue(val[k])}
+		}
+		return otellog.MapValue(kvs...)
+	case string:
+		return otellog.StringValue(val)
+	case bool:
+		return otellog.BoolValue(val)
+	}
+	return otellog.Value{}
+}
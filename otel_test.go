@@ -0,0 +1,195 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// memoryExporter guarda em memória os registros exportados pelo SDK.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+	flushes int
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error { return nil }
+
+func (e *memoryExporter) ForceFlush(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.flushes++
+	return nil
+}
+
+func newOTelProvider() (*sdklog.LoggerProvider, *memoryExporter) {
+	exp := &memoryExporter{}
+	return sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp))), exp
+}
+
+func otelAttrs(r sdklog.Record) map[string]otellog.Value {
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestSink_OTelLogRecord(t *testing.T) {
+	provider, exp := newOTelProvider()
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+
+	l := NewLogger(WithClock(fixedClock), WithLevel(LevelDebug), WithSinks(
+		Sink{LoggerProvider: provider, Level: LevelDebug},
+	)).With("svc", "api")
+	err := fmt.Errorf("save: %w", errors.New("disk full"))
+	l.WarnCtx(ctx, "slow query", "ms", 950, "ratio", 0.5, "cached", false,
+		"tags", []string{"a", "b"}, "payload", []byte("raw"), Err(err))
+
+	if len(exp.records) != 1 {
+		t.Fatalf("esperado 1 registro, obteve %d", len(exp.records))
+	}
+	r := exp.records[0]
+	if r.Severity() != otellog.SeverityWarn1 || r.SeverityText() != "WARN" {
+		t.Errorf("severidade = %v %q", r.Severity(), r.SeverityText())
+	}
+	if r.Body().AsString() != "slow query" || !r.Timestamp().Equal(fixedClock()) {
+		t.Errorf("body/timestamp = %q %v", r.Body().AsString(), r.Timestamp())
+	}
+	if r.TraceID() != traceID || r.SpanID() != spanID || r.TraceFlags() != trace.FlagsSampled {
+		t.Errorf("trace context = %v %v %v", r.TraceID(), r.SpanID(), r.TraceFlags())
+	}
+	if r.InstrumentationScope().Name != otelScope {
+		t.Errorf("scope = %q", r.InstrumentationScope().Name)
+	}
+
+	attrs := otelAttrs(r)
+	if attrs["svc"].AsString() != "api" || attrs["ms"].AsInt64() != 950 ||
+		attrs["ratio"].AsFloat64() != 0.5 || attrs["cached"].Kind() != otellog.KindBool {
+		t.Errorf("atributos escalares = %v", attrs)
+	}
+	if tags := attrs["tags"].AsSlice(); len(tags) != 2 || tags[1].AsString() != "b" {
+		t.Errorf("tags = %v", attrs["tags"])
+	}
+	if string(attrs["payload"].AsBytes()) != "raw" {
+		t.Errorf("payload = %v", attrs["payload"])
+	}
+	errAttr := map[string]otellog.Value{}
+	for _, kv := range attrs["error"].AsMap() {
+		errAttr[kv.Key] = kv.Value
+	}
+	if errAttr["message"].AsString() != "save: disk full" || errAttr["type"].AsString() != "*fmt.wrapError" ||
+		len(errAttr["chain"].AsSlice()) != 1 {
+		t.Errorf("error = %v", attrs["error"])
+	}
+}
+
+func TestSink_OTelSeverityAndFiltering(t *testing.T) {
+	provider, exp := newOTelProvider()
+	var buf bytes.Buffer
+	l := NewLogger(WithLevel(LevelTrace), WithExitFunc(func(int) {}),
+		WithCustomLevel(Level(6), "NOTICE", ""),
+		WithSinks(
+			Sink{LoggerProvider: provider, Level: LevelDebug},
+			Sink{Writer: &buf, Level: LevelError, Format: "{message}"},
+		))
+	l.Trace("filtrado")
+	l.Debug("d")
+	l.Log(Level(6), "n")
+	l.Error("e")
+	l.Fatal("f")
+
+	want := []struct {
+		sev  otellog.Severity
+		text string
+	}{
+		{otellog.SeverityDebug1, "DEBUG"},
+		{otellog.SeverityWarn3, "NOTICE"},
+		{otellog.SeverityError1, "ERROR"},
+		{otellog.SeverityFatal4, "FATAL"},
+	}
+	if len(exp.records) != len(want) {
+		t.Fatalf("esperado %d registros, obteve %d", len(want), len(exp.records))
+	}
+	for i, w := range want {
+		if r := exp.records[i]; r.Severity() != w.sev || r.SeverityText() != w.text {
+			t.Errorf("registro %d: %v %q, esperado %v %q", i, r.Severity(), r.SeverityText(), w.sev, w.text)
+		}
+	}
+	if buf.String() != "e\nf\n" {
+		t.Errorf("sink de texto = %q", buf.String())
+	}
+
+	before := exp.flushes
+	if err := l.Flush(); err != nil || exp.flushes != before+1 {
+		t.Errorf("Flush deveria chamar ForceFlush do provider: err=%v flushes=%d", err, exp.flushes-before)
+	}
+}
+
+func TestSink_OTelRedactionAndReplayedTrace(t *testing.T) {
+	provider, exp := newOTelProvider()
+	l := NewLogger(WithRedaction(RedactRule{Keys: []string{"password"}}), WithSinks(Sink{LoggerProvider: provider}))
+	l.Info("login", "password", "hunter2")
+
+	r := Record{Level: LevelInfo, Message: "replayed",
+		TraceID: "0102030405060708090a0b0c0d0e0f10", SpanID: "0102030405060708"}
+	l.LogRecord(context.Background(), r)
+
+	if len(exp.records) != 2 {
+		t.Fatalf("esperado 2 registros, obteve %d", len(exp.records))
+	}
+	if got := otelAttrs(exp.records[0])["password"].AsString(); got != "***" {
+		t.Errorf("redação não aplicada antes do export: %q", got)
+	}
+	if got := exp.records[1].TraceID().String(); !strings.HasPrefix(got, "0102") {
+		t.Errorf("trace_id do Record deveria ser exportado, obteve %q", got)
+	}
+}
+
+func TestTypedValue_SharedBySpanAndOTel(t *testing.T) {
+	r := &Record{}
+	cases := []struct {
+		in   any
+		otel otellog.Value
+		span attribute.Value
+	}{
+		{uint64(math.MaxUint64), otellog.StringValue("18446744073709551615"), attribute.StringValue("18446744073709551615")},
+		{uint(7), otellog.Int64Value(7), attribute.Int64Value(7)},
+		{[]int{1, 2}, otellog.SliceValue(otellog.Int64Value(1), otellog.Int64Value(2)), attribute.Int64SliceValue([]int64{1, 2})},
+		{1500 * time.Millisecond, otellog.StringValue("1.5s"), attribute.StringValue("1.5s")},
+		{float32(0.5), otellog.Float64Value(0.5), attribute.Float64Value(0.5)},
+	}
+	for _, c := range cases {
+		if got := otelValue(c.in, r); !got.Equal(c.otel) {
+			t.Errorf("otelValue(%#v) = %v, esperado %v", c.in, got, c.otel)
+		}
+		if got := spanEventAttrs("k", c.in, r); len(got) != 1 || got[0].Value != c.span {
+			t.Errorf("spanEventAttrs(%#v) = %v, esperado %v", c.in, got, c.span)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package otelsdk integra o wslogger ao SDK de trace do OpenTelemetry.
+// Fica num pacote separado para que o wslogger dependa apenas da API do
+// OTel; quem usa o SDK registra o SpanAttributeProcessor no
+// TracerProvider e o passa a wslogger.WithSpanAttributeSource:
+//
+//	attrs := otelsdk.NewSpanAttributeProcessor()
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(attrs))
+//	log := wslogger.NewLogger(wslogger.WithSpanAttributeSource(attrs))
+package otelsdk
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanAttributeProcessor é um sdktrace.SpanProcessor que acompanha os
+// spans gravados entre o início e o fim, permitindo ler seus atributos a
+// partir do SpanContext (wslogger.SpanAttributeSource). Spans não
+// amostrados não passam pelos processors e não têm atributos.
+type SpanAttributeProcessor struct {
+	mu    sync.RWMutex
+	spans map[spanKey]sdktrace.ReadOnlySpan
+}
+
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+var _ sdktrace.SpanProcessor = (*SpanAttributeProcessor)(nil)
+
+// NewSpanAttributeProcessor cria o processor; registre-o com
+// sdktrace.WithSpanProcessor.
+func NewSpanAttributeProcessor() *SpanAttributeProcessor {
+	return &SpanAttributeProcessor{spans: make(map[spanKey]sdktrace.ReadOnlySpan)}
+}
+
+// SpanAttributes retorna os atributos atuais do span identificado por sc,
+// incluindo os definidos após o início, ou nil se o span não está ativo.
+func (p *SpanAttributeProcessor) SpanAttributes(sc trace.SpanContext) []attribute.KeyValue {
+	p.mu.RLock()
+	s, ok := p.spans[spanKey{sc.TraceID(), sc.SpanID()}]
+	p.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return s.Attributes()
+}
+
+// OnStart passa a acompanhar o span.
+func (p *SpanAttributeProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	sc := s.SpanContext()
+	p.mu.Lock()
+	p.spans[spanKey{sc.TraceID(), sc.SpanID()}] = s
+	p.mu.Unlock()
+}
+
+// OnEnd deixa de acompanhar o span.
+func (p *SpanAttributeProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sc := s.SpanContext()
+	p.mu.Lock()
+	delete(p.spans, spanKey{sc.TraceID(), sc.SpanID()})
+	p.mu.Unlock()
+}
+
+// Shutdown descarta os spans acompanhados.
+func (p *SpanAttributeProcessor) Shutdown(context.Context) error {
+	p.mu.Lock()
+	p.spans = make(map[spanKey]sdktrace.ReadOnlySpan)
+	p.mu.Unlock()
+	return nil
+}
+
+// ForceFlush não faz nada: o processor não exporta spans.
+func (p *SpanAttributeProcessor) ForceFlush(context.Context) error { return nil }
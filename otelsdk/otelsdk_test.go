@@ -0,0 +1,43 @@
+package otelsdk
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanAttributeProcessor(t *testing.T) {
+	p := NewSpanAttributeProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	_, span := tp.Tracer("test").Start(context.Background(), "op", trace.WithAttributes(attribute.Int("a", 1)))
+	span.SetAttributes(attribute.Bool("b", true))
+
+	sc := span.SpanContext()
+	attrs := p.SpanAttributes(sc)
+	if len(attrs) != 2 || attrs[0] != attribute.Int("a", 1) || attrs[1] != attribute.Bool("b", true) {
+		t.Errorf("atributos = %v", attrs)
+	}
+
+	span.End()
+	if attrs := p.SpanAttributes(sc); attrs != nil {
+		t.Errorf("span encerrado não deveria ter atributos: %v", attrs)
+	}
+	if n := len(p.spans); n != 0 {
+		t.Errorf("OnEnd deveria remover o span, restam %d", n)
+	}
+}
+
+func TestSpanAttributeProcessor_Shutdown(t *testing.T) {
+	p := NewSpanAttributeProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	_, span := tp.Tracer("test").Start(context.Background(), "op")
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if attrs := p.SpanAttributes(span.SpanContext()); attrs != nil || len(p.spans) != 0 {
+		t.Errorf("Shutdown deveria descartar os spans: %v", attrs)
+	}
+}
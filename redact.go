@@ -0,0 +1,271 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Padrões prontos para uso em RedactRule.Values.
+var (
+	PatternCardNumber = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	PatternCPF        = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`)
+	PatternEmail      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// defaultMask substitui valores mascarados quando RedactRule.Mask é vazio.
+const defaultMask = "***"
+
+// RedactRule descreve o que mascarar e como.
+type RedactRule struct {
+	// Keys são padrões glob (path.Match) comparados sem diferenciar
+	// maiúsculas com a chave completa e com o último segmento de chaves
+	// agrupadas ("user.password" casa com "password"). O valor inteiro de
+	// uma chave que casa é mascarado, qualquer que seja o tipo. Chaves de
+	// maps e structs aninhados também são comparadas, pelo caminho
+	// completo ("meta.password") e pelo último segmento.
+	Keys []string
+	// Values mascara os trechos que casam na mensagem, na forma textual
+	// dos extras (strings, números, []byte e fmt.Stringer), nas folhas de
+	// maps, structs e slices e nas mensagens de erros (incluindo a cadeia).
+	Values []*regexp.Regexp
+	// KeepLast preserva os últimos caracteres do valor mascarado (ex.: 4
+	// para cartões: "***1234"). Zero mascara tudo.
+	KeepLast int
+	// Mask é o texto que substitui o conteúdo mascarado; padrão "***".
+	Mask string
+}
+
+// WithRedaction mascara dados sensíveis de acordo com rules. A redação é
+// aplicada após os hooks e imediatamente antes da codificação, igualmente
+// nos modos texto e JSON. Pode ser chamada várias vezes; as regras se
+// acumulam.
+func WithRedaction(rules ...RedactRule) Option {
+	return func(l *Logger) {
+		r := &redactor{}
+		if l.redactor != nil {
+			r.rules = append(r.rules, l.redactor.rules...)
+		}
+		for _, rule := range rules {
+			keys := make([]string, len(rule.Keys))
+			for i, k := range rule.Keys {
+				keys[i] = strings.ToLower(k)
+			}
+			rule.Keys = keys
+			if rule.Mask == "" {
+				rule.Mask = defaultMask
+			}
+			r.rules = append(r.rules, rule)
+		}
+		for _, rule := range r.rules {
+			r.hasValues = r.hasValues || len(rule.Values) > 0
+			r.hasKeys = r.hasKeys || len(rule.Keys) > 0
+		}
+		l.redactor = r
+	}
+}
+
+// redactor aplica as regras de WithRedaction; é imutável após a
+// construção e compartilhado com loggers derivados.
+type redactor struct {
+	rules     []RedactRule
+	hasValues bool
+	hasKeys   bool
+}
+
+// apply mascara a mensagem e os campos do Record.
+func (rd *redactor) apply(r *Record) {
+	r.Message = rd.redactString(r.Message)
+	for i, kv := range r.fields {
+		if rule, ok := rd.matchKey(kv.key); ok {
+			r.fields[i].value = rule.mask(fmt.Sprint(kv.value))
+			continue
+		}
+		if v, ok := kv.value.(error); ok {
+			if rd.hasValues {
+				info := newErrorInfo(v, r.stack)
+				info.Message = rd.redactString(info.Message)
+				for j := range info.Chain {
+					info.Chain[j].Message = rd.redactString(info.Chain[j].Message)
+				}
+				r.fields[i].value = info
+			}
+			continue
+		}
+		if v, changed := rd.redactValue(kv.key, kv.value); changed {
+			r.fields[i].value = v
+		}
+	}
+}
+
+// redactValue aplica as regras a um extra que não é erro. O valor só é
+// substituído (changed) quando algo foi mascarado, preservando o tipo
+// original nos demais casos.
+func (rd *redactor) redactValue(key string, v any) (any, bool) {
+	switch val := v.(type) {
+	case nil:
+		return v, false
+	case string:
+		red := rd.redactString(val)
+		return red, red != val
+	case []byte:
+		return rd.redactText(string(val))
+	case fmt.Stringer:
+		if red, changed := rd.redactText(safeString(val, "String", val.String)); changed {
+			return red, true
+		}
+	}
+	switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		return rd.redactComposite(key, v)
+	case reflect.Invalid:
+		return v, false
+	}
+	return rd.redactText(fmt.Sprint(v))
+}
+
+// redactText mascara a forma textual de um valor; changed indica se
+// algum padrão casou.
+func (rd *redactor) redactText(s string) (any, bool) {
+	if !rd.hasValues {
+		return nil, false
+	}
+	red := rd.redactString(s)
+	return red, red != s
+}
+
+// redactComposite percorre maps, structs e slices pela sua forma JSON (a
+// mesma emitida pelo modo JSON), mascarando chaves aninhadas e folhas.
+// O resultado preserva a ordem dos campos.
+func (rd *redactor) redactComposite(key string, v any) (any, bool) {
+	raw, ok := jsonValue(v).(json.RawMessage)
+	if !ok {
+		return v, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	tree, err := decodeOrdered(dec)
+	if err != nil {
+		return v, false
+	}
+	return rd.redactTree(key, tree)
+}
+
+func (rd *redactor) redactTree(keyPath string, v any) (any, bool) {
+	switch val := v.(type) {
+	case orderedFields:
+		changed := false
+		for i, kv := range val {
+			child := keyPath + "." + kv.key
+			if rd.hasKeys {
+				if rule, ok := rd.matchKey(child); ok {
+					val[i].value = rule.mask(fmt.Sprint(kv.value))
+					changed = true
+					continue
+				}
+			}
+			if red, ok := rd.redactTree(child, kv.value); ok {
+				val[i].value, changed = red, true
+			}
+		}
+		return val, changed
+	case []any:
+		changed := false
+		for i, e := range val {
+			if red, ok := rd.redactTree(keyPath, e); ok {
+				val[i], changed = red, true
+			}
+		}
+		return val, changed
+	case string:
+		return rd.redactText(val)
+	case json.Number:
+		return rd.redactText(val.String())
+	}
+	return v, false
+}
+
+// decodeOrdered lê um valor JSON mantendo a ordem das chaves dos objetos
+// (como orderedFields); números são json.Number.
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := orderedFields{}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, KeyValuePair{fmt.Sprint(k), val})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			val, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+func (rd *redactor) matchKey(key string) (RedactRule, bool) {
+	key = strings.ToLower(key)
+	last := key
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		last = key[i+1:]
+	}
+	for _, rule := range rd.rules {
+		for _, pattern := range rule.Keys {
+			if globMatch(pattern, key) || (last != key && globMatch(pattern, last)) {
+				return rule, true
+			}
+		}
+	}
+	return RedactRule{}, false
+}
+
+func globMatch(pattern, s string) bool {
+	ok, err := path.Match(pattern, s)
+	return err == nil && ok
+}
+
+func (rd *redactor) redactString(s string) string {
+	for _, rule := range rd.rules {
+		for _, re := range rule.Values {
+			s = re.ReplaceAllStringFunc(s, rule.mask)
+		}
+	}
+	return s
+}
+
+// mask substitui s pela máscara, preservando os últimos KeepLast
+// caracteres quando s é maior que isso.
+func (rule RedactRule) mask(s string) string {
+	if rule.KeepLast <= 0 {
+		return rule.Mask
+	}
+	runes := []rune(s)
+	if len(runes) <= rule.KeepLast {
+		return rule.Mask
+	}
+	return rule.Mask + string(runes[len(runes)-rule.KeepLast:])
+}
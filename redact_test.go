@@ -0,0 +1,152 @@
+package wslogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogger_RedactionKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithRedaction(
+		RedactRule{Keys: []string{"password", "authorization", "*token*"}},
+	))
+	l.Info("login", "user", "john", "Password", "s3cret", "Authorization", "Bearer abc",
+		"refresh_token", 123, "tokenless", "ok")
+
+	want := `login user=john Password=*** Authorization=*** refresh_token=*** tokenless=***`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+func TestLogger_RedactionValuesInMessageAndExtras(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithRedaction(
+		RedactRule{Values: []*regexp.Regexp{PatternEmail, PatternCPF}},
+		RedactRule{Values: []*regexp.Regexp{PatternCardNumber}, KeepLast: 4},
+	))
+	l.Info("cadastro de john@example.com", "cpf", "123.456.789-09", "card", "4111 1111 1111 1234", "n", 42)
+
+	want := `cadastro de *** cpf=*** card=***1234 n=42`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+func TestLogger_RedactionJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true), WithRedaction(
+		RedactRule{Keys: []string{"card_*"}, KeepLast: 4, Mask: "####"},
+		RedactRule{Values: []*regexp.Regexp{PatternEmail}},
+	))
+	l.Info("user john@example.com paid", "card_number", 4111111111111234, "email", "john@example.com", "amount", 10.5)
+
+	var got struct {
+		Message string         `json:"message"`
+		Extra   map[string]any `json:"extra"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("JSON inválido: %v (%s)", err, buf.String())
+	}
+	if got.Message != "user *** paid" {
+		t.Errorf("message = %q", got.Message)
+	}
+	if got.Extra["card_number"] != "####1234" || got.Extra["email"] != "***" || got.Extra["amount"] != 10.5 {
+		t.Errorf("extra = %v", got.Extra)
+	}
+}
+
+type contactStringer struct{ email string }
+
+func (c contactStringer) String() string { return "contato " + c.email }
+
+func TestLogger_RedactionNestedAndNonStringValues(t *testing.T) {
+	type credentials struct {
+		User          string `json:"user"`
+		Authorization string `json:"authorization"`
+	}
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true), WithRedaction(
+		RedactRule{Keys: []string{"password", "authorization"}},
+		RedactRule{Values: []*regexp.Regexp{PatternEmail}},
+		RedactRule{Values: []*regexp.Regexp{PatternCardNumber}, KeepLast: 4},
+	))
+	l.Info("pagamento",
+		"card", int64(4111111111111111),
+		"meta", map[string]any{"password": "x", "email": "a@b.com", "tags": []string{"c@d.com"}},
+		"creds", credentials{User: "ana", Authorization: "Bearer abc"},
+		"raw", []byte("a@b.com"),
+		"contact", contactStringer{"a@b.com"},
+		"amount", 10.5,
+	)
+
+	out := buf.String()
+	for _, want := range []string{
+		`"card":"***1111"`,
+		`"meta":{"email":"***","password":"***","tags":["***"]}`,
+		`"creds":{"user":"ana","authorization":"***"}`,
+		`"raw":"***"`,
+		`"contact":"contato ***"`,
+		`"amount":10.5`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("esperado %s em %s", want, out)
+		}
+	}
+	for _, leak := range []string{"a@b.com", "c@d.com", "Bearer", "4111111111111111"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("valor sensível vazou (%s): %s", leak, out)
+		}
+	}
+
+	buf.Reset()
+	l.SetJSON(false)
+	l.Info("texto", "meta", map[string]any{"password": "x", "n": 1})
+	if got := strings.TrimSpace(buf.String()); !strings.HasSuffix(got, "meta=map[n:1 password:***]") {
+		t.Errorf("texto = %q", got)
+	}
+}
+
+type ptrStringer struct{ s string }
+
+func (p *ptrStringer) String() string { return p.s }
+
+func TestLogger_RedactionNilPointerValues(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"),
+		WithRedaction(RedactRule{Values: []*regexp.Regexp{PatternEmail}}))
+	l.Info("x", "s", (*ptrStringer)(nil), "err", (*ptrError)(nil), "ok", &ptrStringer{"a@b.com"})
+	want := "x s=<nil> err=<nil> err.type=*wslogger.ptrError ok=***"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+func TestLogger_RedactionAppliesToBoundFieldsAndSlogGroups(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"),
+		WithRedaction(RedactRule{Keys: []string{"PASSWORD"}}),
+		WithRedaction(RedactRule{Keys: []string{"api_key"}}),
+	).With("api_key", "k-123")
+
+	l.Slog().Info("db", slog.Group("db", "user", "app", "password", "pw"))
+
+	want := `db api_key=*** db.user=app db.password=***`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("saída = %q\nesperado %q", got, want)
+	}
+}
+
+func TestRedactRule_Mask(t *testing.T) {
+	r := RedactRule{Mask: "***", KeepLast: 4}
+	if got := r.mask("1234"); got != "***" {
+		t.Errorf("valor curto deveria ser todo mascarado, obteve %q", got)
+	}
+	if got := r.mask("número 987654"); got != "***7654" {
+		t.Errorf("mask = %q", got)
+	}
+}
@@ -0,0 +1,404 @@
+package wslogger
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Sampler decide, antes de qualquer formatação, se um registro deve ser
+// emitido. key identifica registros semelhantes: a mensagem (primeiro
+// argumento) nos métodos comuns e o template de formato nos métodos *f.
+// Quando o registro é emitido, suppressed informa quantos registros com o
+// mesmo nível e chave foram descartados desde o último emitido; o Logger
+// escreve então um resumo "suppressed N similar messages" antes dele.
+type Sampler interface {
+	Sample(level Level, key string) (keep bool, suppressed uint64)
+}
+
+// SuppressedReporter é implementado por samplers que acumulam descartes
+// por nível+chave, como os embutidos. O Logger chama TakeSuppressed
+// periodicamente (ver WithSamplerSummaryInterval) e em Flush/Close, e
+// escreve o resumo das chaves que não voltaram a ser emitidas; os
+// contadores devolvidos são zerados.
+type SuppressedReporter interface {
+	TakeSuppressed() []Suppressed
+}
+
+// Suppressed é o total de registros descartados de um nível+chave.
+type Suppressed struct {
+	Level Level
+	Key   string
+	Count uint64
+}
+
+// defaultSummaryInterval é o atraso padrão entre o primeiro descarte e a
+// escrita dos resumos pendentes.
+const defaultSummaryInterval = 10 * time.Second
+
+// WithSampler ativa amostragem/limitação de taxa. PANIC e FATAL nunca são
+// amostrados. O estado do sampler é compartilhado com loggers derivados, e
+// os samplers embutidos passam a medir o tempo pelo relógio de WithClock.
+func WithSampler(s Sampler) Option {
+	return func(l *Logger) { l.sampler = s }
+}
+
+// WithSamplerSummaryInterval define quanto tempo após um descarte os
+// resumos pendentes são escritos, mesmo que a chave não volte a ser
+// emitida (padrão 10s). Vale para samplers que implementam
+// SuppressedReporter.
+func WithSamplerSummaryInterval(d time.Duration) Option {
+	return func(l *Logger) {
+		if d > 0 {
+			l.summaryInterval = d
+		}
+	}
+}
+
+type samplerKey struct {
+	level Level
+	key   string
+}
+
+// sample consulta o sampler configurado, se houver.
+func (l *Logger) sample(level Level, key string) (uint64, bool) {
+	if l.sampler == nil || level >= LevelPanic {
+		return 0, true
+	}
+	keep, suppressed := l.sampler.Sample(level, key)
+	if !keep && l.summary != nil {
+		l.summary.schedule()
+	}
+	return suppressed, keep
+}
+
+// sampleSummary escreve, pelo logger raiz, os descartes pendentes de um
+// SuppressedReporter. Um timer é armado no primeiro descarte e desarmado
+// ao disparar, então não há goroutine enquanto nada é suprimido.
+type sampleSummary struct {
+	logger   *Logger
+	reporter SuppressedReporter
+	interval time.Duration
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+func newSampleSummary(l *Logger) *sampleSummary {
+	r, ok := l.sampler.(SuppressedReporter)
+	if !ok {
+		return nil
+	}
+	interval := l.summaryInterval
+	if interval <= 0 {
+		interval = defaultSummaryInterval
+	}
+	return &sampleSummary{logger: l, reporter: r, interval: interval}
+}
+
+func (s *sampleSummary) schedule() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		return
+	}
+	s.timer = time.AfterFunc(s.interval, func() {
+		s.mu.Lock()
+		s.timer = nil
+		s.mu.Unlock()
+		s.flush()
+	})
+}
+
+// flush escreve um resumo por nível+chave com descartes pendentes. O
+// callsite original não é conhecido, então o caller sai como "unknown".
+func (s *sampleSummary) flush() {
+	l := s.logger
+	for _, p := range s.reporter.TakeSuppressed() {
+		l.logInternal(summaryEntry(entry{time: l.now(), level: p.Level, msg: p.Key}, l.fields, p.Count),
+			context.Background())
+	}
+}
+
+// stop desarma o timer pendente; descartes posteriores voltam a armá-lo.
+func (s *sampleSummary) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// sortSuppressed ordena os descartes por nível e chave, para que os
+// resumos saiam em ordem estável.
+func sortSuppressed(out []Suppressed) []Suppressed {
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Level != out[j].Level {
+			return out[i].Level < out[j].Level
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// messageKey extrai a chave de amostragem dos argumentos de log sem
+// processar os extras.
+func messageKey(args []any) string {
+	if len(args) == 0 {
+		return ""
+	}
+	if s, ok := args[0].(string); ok {
+		return s
+	}
+	return fmt.Sprint(args[0])
+}
+
+// summaryEntry monta o registro de resumo emitido antes de e quando
+// registros semelhantes foram suprimidos. Carrega apenas os campos fixos
+// do logger e a mensagem de referência.
+func summaryEntry(e entry, fields []KeyValuePair, suppressed uint64) entry {
+	extras := make([]KeyValuePair, 0, len(fields)+1)
+	extras = append(extras, fields...)
+	extras = append(extras, KeyValuePair{"sampled_message", e.msg})
+	return entry{
+		time:   e.time,
+		level:  e.level,
+		msg:    fmt.Sprintf("suppressed %d similar messages", suppressed),
+		extras: extras,
+		frame:  e.frame,
+	}
+}
+
+// ==== Samplers embutidos ======
+
+// defaultSampleInterval substitui intervalos não positivos em
+// NewCountSampler.
+const defaultSampleInterval = time.Second
+
+// clockSetter é implementado pelos samplers embutidos para que NewLogger
+// lhes entregue o relógio de WithClock.
+type clockSetter interface {
+	setClock(now func() time.Time)
+}
+
+// NewCountSampler emite, para cada nível+chave, os first primeiros
+// registros de cada intervalo e depois um a cada thereafter (zero descarta
+// todos os demais até o próximo intervalo). Um interval não positivo
+// desligaria a amostragem em silêncio, então vira 1s.
+func NewCountSampler(interval time.Duration, first, thereafter int) Sampler {
+	if interval <= 0 {
+		interval = defaultSampleInterval
+	}
+	return &countSampler{
+		clock:      time.Now,
+		interval:   interval,
+		first:      uint64(max(first, 0)),
+		thereafter: uint64(max(thereafter, 0)),
+		entries:    make(map[samplerKey]*countEntry),
+	}
+}
+
+type countSampler struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+
+	mu        sync.Mutex
+	clock     func() time.Time
+	entries   map[samplerKey]*countEntry
+	lastSweep time.Time
+}
+
+func (s *countSampler) setClock(now func() time.Time) {
+	s.mu.Lock()
+	s.clock = now
+	s.mu.Unlock()
+}
+
+type countEntry struct {
+	start      time.Time
+	n          uint64
+	suppressed uint64
+}
+
+func (s *countSampler) Sample(level Level, key string) (bool, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock()
+	s.sweep(now)
+
+	k := samplerKey{level, key}
+	e := s.entries[k]
+	if e == nil {
+		e = &countEntry{start: now}
+		s.entries[k] = e
+	} else if now.Sub(e.start) >= s.interval {
+		e.start, e.n = now, 0
+	}
+	e.n++
+	if e.n <= s.first || (s.thereafter > 0 && (e.n-s.first)%s.thereafter == 0) {
+		suppressed := e.suppressed
+		e.suppressed = 0
+		return true, suppressed
+	}
+	e.suppressed++
+	return false, 0
+}
+
+// TakeSuppressed devolve e zera os descartes pendentes de cada chave.
+func (s *countSampler) TakeSuppressed() []Suppressed {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Suppressed
+	for k, e := range s.entries {
+		if e.suppressed > 0 {
+			out = append(out, Suppressed{k.level, k.key, e.suppressed})
+			e.suppressed = 0
+		}
+	}
+	return sortSuppressed(out)
+}
+
+// sweep remove, no máximo uma vez por intervalo, as chaves cujo intervalo
+// terminou há mais de um intervalo, limitando o crescimento do mapa
+// quando as mensagens são dinâmicas. Chaves com descartes ainda não
+// reportados são mantidas até TakeSuppressed.
+func (s *countSampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+	s.lastSweep = now
+	for k, e := range s.entries {
+		if e.suppressed == 0 && now.Sub(e.start) >= 2*s.interval {
+			delete(s.entries, k)
+		}
+	}
+}
+
+// RateLimit configura um token bucket: PerSecond tokens por segundo com
+// capacidade Burst.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// NewTokenBucketSampler limita a taxa de registros por nível com um token
+// bucket por nível. Níveis ausentes de limits não são limitados.
+func NewTokenBucketSampler(limits map[Level]RateLimit) Sampler {
+	s := &tokenBucketSampler{
+		clock:      time.Now,
+		buckets:    make(map[Level]*tokenBucket, len(limits)),
+		suppressed: newSuppressedCounter(),
+	}
+	for lv, rl := range limits {
+		s.buckets[lv] = &tokenBucket{limit: rl, tokens: float64(rl.Burst)}
+	}
+	return s
+}
+
+type tokenBucketSampler struct {
+	mu         sync.Mutex
+	clock      func() time.Time
+	buckets    map[Level]*tokenBucket
+	suppressed *suppressedCounter
+}
+
+func (s *tokenBucketSampler) setClock(now func() time.Time) {
+	s.mu.Lock()
+	s.clock = now
+	s.mu.Unlock()
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func (s *tokenBucketSampler) Sample(level Level, key string) (bool, uint64) {
+	s.mu.Lock()
+	b := s.buckets[level]
+	keep := b == nil || b.take(s.clock())
+	s.mu.Unlock()
+	return s.suppressed.record(samplerKey{level, key}, keep)
+}
+
+// TakeSuppressed devolve e zera os descartes pendentes de cada chave.
+func (s *tokenBucketSampler) TakeSuppressed() []Suppressed { return s.suppressed.take() }
+
+func (b *tokenBucket) take(now time.Time) bool {
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.PerSecond
+		if max := float64(b.limit.Burst); b.tokens > max {
+			b.tokens = max
+		}
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+	return false
+}
+
+// NewProbabilisticSampler emite cada registro com probabilidade rate
+// (entre 0 e 1).
+func NewProbabilisticSampler(rate float64) Sampler {
+	return &probabilisticSampler{rate: rate, suppressed: newSuppressedCounter()}
+}
+
+type probabilisticSampler struct {
+	rate       float64
+	suppressed *suppressedCounter
+}
+
+func (s *probabilisticSampler) Sample(level Level, key string) (bool, uint64) {
+	keep := s.rate >= 1 || (s.rate > 0 && rand.Float64() < s.rate)
+	return s.suppressed.record(samplerKey{level, key}, keep)
+}
+
+// TakeSuppressed devolve e zera os descartes pendentes de cada chave.
+func (s *probabilisticSampler) TakeSuppressed() []Suppressed { return s.suppressed.take() }
+
+// suppressedCounter acumula descartes por nível+chave até o próximo
+// registro emitido com a mesma chave ou até take, que esvazia o mapa.
+type suppressedCounter struct {
+	mu     sync.Mutex
+	counts map[samplerKey]uint64
+}
+
+func newSuppressedCounter() *suppressedCounter {
+	return &suppressedCounter{counts: make(map[samplerKey]uint64)}
+}
+
+func (c *suppressedCounter) record(k samplerKey, keep bool) (bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !keep {
+		c.counts[k]++
+		return false, 0
+	}
+	n := c.counts[k]
+	if n > 0 {
+		delete(c.counts, k)
+	}
+	return true, n
+}
+
+// take devolve os descartes pendentes e troca o mapa por um vazio, de modo
+// que chaves dinâmicas não o façam crescer indefinidamente.
+func (c *suppressedCounter) take() []Suppressed {
+	c.mu.Lock()
+	counts := c.counts
+	c.counts = make(map[samplerKey]uint64)
+	c.mu.Unlock()
+	out := make([]Suppressed, 0, len(counts))
+	for k, n := range counts {
+		out = append(out, Suppressed{k.level, k.key, n})
+	}
+	return sortSuppressed(out)
+}
@@ -0,0 +1,277 @@
+package wslogger
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLogger_CountSampler(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{level} {message} {extra}"),
+		WithSampler(NewCountSampler(time.Hour, 2, 3)))
+
+	for i := 0; i < 10; i++ {
+		l.Warn("hot loop", "i", i)
+	}
+	l.Info("hot loop") // outro nível, outra chave
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// emitidos: 1, 2, 5, 8 (WARN) + INFO; antes do 5º e do 8º, um resumo
+	want := []string{
+		"WARN hot loop i=0",
+		"WARN hot loop i=1",
+		"WARN suppressed 2 similar messages sampled_message=\"hot loop\"",
+		"WARN hot loop i=4",
+		"WARN suppressed 2 similar messages sampled_message=\"hot loop\"",
+		"WARN hot loop i=7",
+		"INFO hot loop",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("esperado %d linhas, obteve %d:\n%s", len(want), len(lines), buf.String())
+	}
+	for i := range want {
+		if strings.TrimSpace(lines[i]) != want[i] {
+			t.Errorf("linha %d = %q, esperado %q", i, lines[i], want[i])
+		}
+	}
+}
+
+// fakeClock é um relógio manual para os samplers, avançado pelo teste.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func TestLogger_CountSamplerNewInterval(t *testing.T) {
+	clock := &fakeClock{t: fixedClock()}
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"), WithClock(clock.now),
+		WithSampler(NewCountSampler(time.Minute, 1, 0)))
+
+	for i := 0; i < 5; i++ {
+		l.Infof("tick %d", i)
+		clock.advance(time.Second)
+	}
+	clock.advance(time.Minute)
+	l.Infof("tick %d", 5)
+
+	want := "tick 0\nsuppressed 4 similar messages\ntick 5\n"
+	if buf.String() != want {
+		t.Errorf("saída = %q, esperado %q", buf.String(), want)
+	}
+}
+
+func TestNewCountSampler_NonPositiveInterval(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		cs := NewCountSampler(d, 1, 0).(*countSampler)
+		if cs.interval != time.Second {
+			t.Errorf("interval %v deveria virar 1s, obteve %v", d, cs.interval)
+		}
+		if keep, _ := cs.Sample(LevelInfo, "a"); !keep {
+			t.Error("o primeiro registro deveria passar")
+		}
+		if keep, _ := cs.Sample(LevelInfo, "a"); keep {
+			t.Error("o segundo registro no mesmo intervalo deveria ser descartado")
+		}
+	}
+}
+
+func TestLogger_TokenBucketSampler(t *testing.T) {
+	clock := &fakeClock{t: fixedClock()}
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{level} {message}"), WithClock(clock.now),
+		WithSampler(NewTokenBucketSampler(map[Level]RateLimit{
+			LevelWarn: {PerSecond: 1, Burst: 3},
+		})))
+
+	for i := 0; i < 10; i++ {
+		l.Warn("disk almost full")
+		l.Info("not limited")
+	}
+	out := buf.String()
+	if n := strings.Count(out, "WARN disk almost full"); n != 3 {
+		t.Errorf("esperado 3 WARN (burst), obteve %d", n)
+	}
+	if n := strings.Count(out, "INFO not limited"); n != 10 {
+		t.Errorf("nível sem limite deveria emitir tudo, obteve %d", n)
+	}
+
+	buf.Reset()
+	clock.advance(2 * time.Second)
+	for i := 0; i < 3; i++ {
+		l.Warn("disk almost full")
+	}
+	if n := strings.Count(buf.String(), "WARN disk almost full"); n != 2 {
+		t.Errorf("após 2s deveriam ser repostos 2 tokens, emitidos %d: %q", n, buf.String())
+	}
+}
+
+func TestLogger_ProbabilisticSampler(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"), WithSampler(NewProbabilisticSampler(0)))
+	for i := 0; i < 100; i++ {
+		l.Info("dropped")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("taxa 0 deveria descartar tudo: %q", buf.String())
+	}
+
+	buf.Reset()
+	l = NewLogger(WithWriter(&buf), WithFormat("{message}"), WithSampler(NewProbabilisticSampler(1)))
+	for i := 0; i < 100; i++ {
+		l.Info("kept")
+	}
+	if n := strings.Count(buf.String(), "kept"); n != 100 {
+		t.Errorf("taxa 1 deveria emitir tudo, obteve %d", n)
+	}
+}
+
+func TestLogger_SamplerNeverDropsFatal(t *testing.T) {
+	var buf bytes.Buffer
+	exits := 0
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"),
+		WithSampler(NewProbabilisticSampler(0)), WithExitFunc(func(int) { exits++ }))
+	l.Fatal("bye")
+	l.Fatal("bye")
+	if exits != 2 || strings.Count(buf.String(), "bye") != 2 {
+		t.Errorf("FATAL não deveria ser amostrado: exits=%d out=%q", exits, buf.String())
+	}
+}
+
+func TestLogger_SamplerSharedWithDerived(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"),
+		WithSampler(NewCountSampler(time.Hour, 1, 2)))
+	child := l.With("req", 1)
+
+	l.Info("same")     // 1º: emitido
+	child.Info("same") // 2º: suprimido
+	child.Info("same") // 3º: emitido com resumo
+
+	want := "same\nsuppressed 1 similar messages req=1 sampled_message=same\nsame req=1\n"
+	if got := strings.ReplaceAll(buf.String(), " \n", "\n"); got != want {
+		t.Errorf("saída = %q, esperado %q", got, want)
+	}
+}
+
+func TestSlogHandler_Sampler(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"),
+		WithSampler(NewCountSampler(time.Hour, 1, 0)))
+	sl := slog.New(NewSlogHandler(l))
+	for i := 0; i < 5; i++ {
+		sl.Info("via slog", "i", i)
+	}
+	if got := strings.Count(buf.String(), "via slog"); got != 1 {
+		t.Errorf("esperado 1 registro via slog, obteve %d: %q", got, buf.String())
+	}
+}
+
+func TestLogger_SamplerSummaryOnFlush(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{level} {message} {extra}"),
+		WithSampler(NewCountSampler(time.Hour, 1, 0)))
+	for i := 0; i < 3; i++ {
+		l.Warn("hot")
+	}
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "WARN hot\nWARN suppressed 2 similar messages sampled_message=hot\n"
+	if got := strings.ReplaceAll(buf.String(), " \n", "\n"); got != want {
+		t.Errorf("saída = %q, esperado %q", got, want)
+	}
+
+	buf.Reset()
+	if err := l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("descartes já reportados não deveriam se repetir: %q", buf.String())
+	}
+}
+
+func TestLogger_SamplerSummaryTimer(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	w := writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.Write(p)
+	})
+	l := NewLogger(WithWriter(w), WithFormat("{message} {extra}"),
+		WithSampler(NewProbabilisticSampler(0)), WithSamplerSummaryInterval(10*time.Millisecond))
+	for i := 0; i < 3; i++ {
+		l.Info("dropped")
+	}
+	l.Error("other")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		got := buf.String()
+		mu.Unlock()
+		if strings.Count(got, "\n") == 2 {
+			want := "suppressed 3 similar messages sampled_message=dropped\nsuppressed 1 similar messages sampled_message=other\n"
+			if got != want {
+				t.Errorf("saída = %q, esperado %q", got, want)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("resumo não foi escrito pelo timer: %q", got)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSampler_SuppressedMapBounded(t *testing.T) {
+	s := NewProbabilisticSampler(0).(*probabilisticSampler)
+	for i := 0; i < 100; i++ {
+		s.Sample(LevelInfo, fmt.Sprintf("user %d", i))
+	}
+	if got := len(s.TakeSuppressed()); got != 100 {
+		t.Errorf("esperado 100 chaves pendentes, obteve %d", got)
+	}
+	if n := len(s.suppressed.counts); n != 0 {
+		t.Errorf("o mapa deveria ser esvaziado após TakeSuppressed, tem %d chaves", n)
+	}
+
+	clock := &fakeClock{t: fixedClock()}
+	cs := NewCountSampler(time.Second, 1, 0).(*countSampler)
+	cs.setClock(clock.now)
+	cs.Sample(LevelInfo, "a")
+	cs.Sample(LevelInfo, "a")
+	clock.advance(3 * time.Second)
+	cs.Sample(LevelInfo, "b")
+	if got := cs.TakeSuppressed(); len(got) != 1 || got[0] != (Suppressed{LevelInfo, "a", 1}) {
+		t.Errorf("sweep não deveria descartar contagens pendentes: %+v", got)
+	}
+}
+
+func BenchmarkLogger_CountSamplerSuppressed(b *testing.B) {
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithSampler(NewCountSampler(time.Hour, 1, 0)))
+	l.Warn("hot")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Warn("hot", "i", i)
+	}
+}
@@ -0,0 +1,90 @@
+package wslogger
+
+import (
+	"context"
+	"io"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// Encoding seleciona o encoder de um Sink.
+type Encoding int
+
+const (
+	// EncodingText usa o template de texto (Sink.Format ou WithFormat).
+	EncodingText Encoding = iota
+	// EncodingJSON emite um objeto JSON por linha.
+	EncodingJSON
+	// EncodingLogfmt emite pares chave=valor no formato logfmt.
+	EncodingLogfmt
+)
+
+// Sink é um destino de saída com nível mínimo, encoder e template
+// próprios.
+//
+// Com LoggerProvider, o sink exporta cada registro como LogRecord do
+// OpenTelemetry em vez de escrevê-lo: severidade (número e nome do
+// nível), mensagem como body, extras como atributos tipados e o trace
+// context do ctx da chamada. Writer, Encoding, Format e Color são então
+// ignorados, e a emissão é síncrona mesmo com WithAsync (use um
+// BatchProcessor no provider).
+type Sink struct {
+	Writer         io.Writer
+	LoggerProvider otellog.LoggerProvider
+	Level          Level    // nível mínimo do destino; o zero é LevelInfo
+	Encoding       Encoding // EncodingText (padrão), EncodingJSON ou EncodingLogfmt
+	Format         string   // template do modo texto; vazio usa o do Logger
+	Color          bool     // cores ANSI no modo texto
+
+	otelLogger otellog.Logger
+}
+
+// WithSinks envia cada registro a vários destinos, cada um com seu nível,
+// encoder e template, por exemplo texto colorido a partir de INFO no
+// terminal e JSON completo a partir de DEBUG em arquivo. Os sinks
+// substituem o writer e as opções WithJSON/WithColor do Logger; app name,
+// formato de hora, hooks e redação continuam valendo para todos. O nível
+// do Logger (WithLevel) filtra antes dos sinks, portanto não deve ser
+// maior que o menor nível entre eles. Sinks sem Writer nem
+// LoggerProvider são ignorados.
+func WithSinks(sinks ...Sink) Option {
+	return func(l *Logger) {
+		out := append([]Sink(nil), l.sinks...)
+		for _, s := range sinks {
+			switch {
+			case s.LoggerProvider != nil:
+				s.otelLogger = s.LoggerProvider.Logger(otelScope)
+				out = append(out, s)
+			case s.Writer != nil:
+				out = append(out, s)
+			}
+		}
+		l.sinks = out
+	}
+}
+
+// writeSinks codifica o Record para cada sink que aceita o nível.
+func (l *Logger) writeSinks(cfg config, r *Record, ctx context.Context) {
+	for _, s := range l.sinks {
+		if r.Level < s.Level {
+			continue
+		}
+		if s.otelLogger != nil {
+			emitOTel(ctx, s.otelLogger, cfg, r)
+			continue
+		}
+		sc := cfg
+		sc.color = s.Color
+		if s.Format != "" {
+			sc.format = s.Format
+		}
+		switch s.Encoding {
+		case EncodingJSON:
+			l.write(s.Writer, l.encodeJSON(sc, r))
+		case EncodingLogfmt:
+			l.write(s.Writer, l.encodeLogfmt(sc, r))
+		default:
+			l.write(s.Writer, l.encodeText(sc, r))
+		}
+	}
+}
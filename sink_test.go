@@ -0,0 +1,120 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLogger_SinksPerLevelAndEncoding(t *testing.T) {
+	var console, file bytes.Buffer
+	l := NewLogger(
+		WithAppName("svc"),
+		WithSinks(
+			Sink{Writer: &console, Level: LevelInfo, Format: "[{level}] {message} {extra}", Color: true},
+			Sink{Writer: &file, Level: LevelDebug, Encoding: EncodingJSON},
+		),
+	)
+	l.Debug("cache miss", "key", "a")
+	l.Info("request done", "status", 200)
+
+	lines := strings.Split(strings.TrimSpace(console.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("console deveria ter apenas INFO+, obteve %q", console.String())
+	}
+	if want := "[" + colorGreen + "INFO" + colorReset + "] request done " + colorGreen + "status" + colorReset + "=200"; lines[0] != want {
+		t.Errorf("console = %q\nesperado %q", lines[0], want)
+	}
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(file.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("linha JSON inválida %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 || records[0]["level"] != "DEBUG" || records[1]["message"] != "request done" {
+		t.Fatalf("arquivo deveria ter DEBUG e INFO em JSON: %v", records)
+	}
+	if records[1]["app_name"] != "svc" || records[1]["extra"].(map[string]any)["status"] != float64(200) {
+		t.Errorf("registro JSON inesperado: %v", records[1])
+	}
+}
+
+func TestLogger_SinksDefaultFormatAndDerived(t *testing.T) {
+	var a, b bytes.Buffer
+	l := NewLogger(WithFormat("{message} {extra}"), WithSinks(Sink{Writer: &a}), WithSinks(Sink{Writer: &b, Level: LevelError}))
+	child := l.With("req", 1)
+	child.Info("one")
+	child.Error("two")
+
+	if got := a.String(); got != "one req=1\ntwo req=1\n" {
+		t.Errorf("sink a = %q", got)
+	}
+	if got := b.String(); got != "two req=1\n" {
+		t.Errorf("sink b = %q", got)
+	}
+}
+
+func TestLogger_SinksAsyncFlush(t *testing.T) {
+	text, js := newGatedWriter(), newGatedWriter()
+	l := NewLogger(WithFormat("{message}"), WithAsync(16, AsyncBlock), WithSinks(
+		Sink{Writer: text},
+		Sink{Writer: js, Encoding: EncodingJSON},
+	))
+	l.Info("queued")
+	close(text.release)
+	close(js.release)
+	if err := l.Close(context.Background()); err != nil {
+		t.Fatalf("Close falhou: %v", err)
+	}
+	if got := text.Lines(); len(got) != 1 || got[0] != "queued" {
+		t.Errorf("sink texto = %v", got)
+	}
+	if got := js.Lines(); len(got) != 1 || !strings.Contains(got[0], `"message":"queued"`) {
+		t.Errorf("sink JSON = %v", got)
+	}
+}
+
+func TestLogger_SinksCallerPerEncoding(t *testing.T) {
+	var text, js, standalone bytes.Buffer
+	l := NewLogger(WithSinks(
+		Sink{Writer: &text, Format: "{caller}"},
+		Sink{Writer: &js, Encoding: EncodingJSON},
+	))
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("hi")
+	NewLogger(WithWriter(&standalone), WithJSON(true)).Info("hi")
+
+	want := "sink_test.go:" + strconv.Itoa(line+1)
+	if got := strings.TrimSpace(text.String()); got != "sink_test.go:TestLogger_SinksCallerPerEncoding:"+strconv.Itoa(line+1) {
+		t.Errorf("caller texto = %q", got)
+	}
+	var rec, ref map[string]any
+	if err := json.Unmarshal(js.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(standalone.Bytes(), &ref); err != nil {
+		t.Fatal(err)
+	}
+	if rec["caller"] != want || ref["caller"] != "sink_test.go:"+strconv.Itoa(line+2) {
+		t.Errorf("caller JSON do sink = %v, WithJSON = %v", rec["caller"], ref["caller"])
+	}
+
+	// um Caller alterado por hook vale para todos os encoders
+	text.Reset()
+	js.Reset()
+	l = NewLogger(WithHooks(HookFunc(func(_ context.Context, r *Record) bool {
+		r.Caller = "custom"
+		return true
+	})), WithSinks(Sink{Writer: &text, Format: "{caller}"}, Sink{Writer: &js, Encoding: EncodingJSON}))
+	l.Info("hi")
+	if strings.TrimSpace(text.String()) != "custom" || !strings.Contains(js.String(), `"caller":"custom"`) {
+		t.Errorf("caller do hook: texto = %q, JSON = %q", text.String(), js.String())
+	}
+}
@@ -0,0 +1,139 @@
+package wslogger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// SlogHandler implementa slog.Handler renderizando os registros pelo
+// mesmo pipeline do Logger: template de texto ou JSON, app name, caller e
+// trace_id/span_id do OpenTelemetry. Atributos e grupos são convertidos em
+// extras, com os nomes dos grupos como prefixo separado por ponto.
+type SlogHandler struct {
+	logger *Logger
+	attrs  []KeyValuePair
+	groups []string
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler cria um slog.Handler que escreve através de l.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{logger: l}
+}
+
+// Slog retorna um *slog.Logger que escreve linhas idênticas às do Logger.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(NewSlogHandler(l))
+}
+
+// Enabled respeita o nível mínimo configurado no Logger. Os níveis do
+// wslogger usam os mesmos valores numéricos do slog.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(Level(level))
+}
+
+// Handle converte o slog.Record e o envia ao Logger.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	suppressed, ok := h.logger.sample(Level(r.Level), r.Message)
+	if !ok {
+		return nil
+	}
+	extras := make([]KeyValuePair, 0, len(h.logger.fields)+len(h.attrs)+r.NumAttrs())
+	extras = append(extras, h.logger.fields...)
+	extras = append(extras, h.attrs...)
+	prefix := groupPrefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		extras = appendSlogAttr(extras, prefix, a)
+		return true
+	})
+
+	t := r.Time
+	if t.IsZero() {
+		t = h.logger.now()
+	}
+	var frame runtime.Frame
+	if r.PC != 0 {
+		frame = lookupFrame(r.PC).frame
+	}
+	e := entry{
+		time:   t,
+		level:  Level(r.Level),
+		msg:    r.Message,
+		extras: extras,
+		frame:  frame,
+	}
+	if suppressed > 0 {
+		h.logger.logInternal(summaryEntry(e, h.logger.fields, suppressed), ctx)
+	}
+	h.logger.logInternal(e, ctx)
+	return nil
+}
+
+// WithAttrs retorna um handler que anexa attrs a todos os registros.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	c := h.clone()
+	prefix := groupPrefix(h.groups)
+	for _, a := range attrs {
+		c.attrs = appendSlogAttr(c.attrs, prefix, a)
+	}
+	return c
+}
+
+// WithGroup retorna um handler cujos atributos seguintes são qualificados
+// pelo nome do grupo.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := h.clone()
+	c.groups = append(c.groups, name)
+	return c
+}
+
+func (h *SlogHandler) clone() *SlogHandler {
+	return &SlogHandler{
+		logger: h.logger,
+		attrs:  append([]KeyValuePair(nil), h.attrs...),
+		groups: append([]string(nil), h.groups...),
+	}
+}
+
+func groupPrefix(groups []string) string {
+	prefix := ""
+	for _, g := range groups {
+		prefix += g + "."
+	}
+	return prefix
+}
+
+// appendSlogAttr achata um slog.Attr (incluindo grupos aninhados) em pares
+// chave/valor, seguindo as regras do slog: atributos vazios são ignorados
+// e grupos sem nome são incorporados ao nível atual.
+func appendSlogAttr(dst []KeyValuePair, prefix string, a slog.Attr) []KeyValuePair {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return dst
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return dst
+		}
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range attrs {
+			dst = appendSlogAttr(dst, prefix, ga)
+		}
+		return dst
+	}
+	return append(dst, KeyValuePair{prefix + a.Key, a.Value.Any()})
+}
@@ -0,0 +1,97 @@
+package wslogger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSlogHandler_IdenticalLines(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(
+		WithWriter(&buf),
+		WithAppName("SlogTest"),
+		WithFormat("[{app_name}] [{level}] {message} {extra}"),
+		WithColor(false),
+	)
+
+	l.Warn("same line", "user", "john")
+	direct := buf.String()
+	buf.Reset()
+
+	l.Slog().Warn("same line", "user", "john")
+	viaSlog := buf.String()
+
+	if direct != viaSlog {
+		t.Errorf("linhas diferentes:\nlogger: %q\nslog:   %q", direct, viaSlog)
+	}
+}
+
+func TestSlogHandler_CallerAndLevel(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithColor(false), WithLevel(LevelInfo))
+	sl := l.Slog()
+
+	sl.Debug("descartado")
+	if buf.Len() != 0 {
+		t.Fatalf("DEBUG deveria ser filtrado pelo nível do Logger: %q", buf.String())
+	}
+
+	sl.Info("with caller")
+	out := buf.String()
+	if !strings.Contains(out, "[slog_test.go:TestSlogHandler_CallerAndLevel:") {
+		t.Errorf("caller deveria apontar para o teste: %q", out)
+	}
+	if !strings.Contains(out, "[INFO]") {
+		t.Errorf("nível INFO esperado: %q", out)
+	}
+}
+
+func TestSlogHandler_AttrsAndGroups(t *testing.T) {
+	var buf strings.Builder
+	l := NewLogger(WithWriter(&buf), WithJSON(true)).With("service", "api")
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("slog-test").Start(context.Background(), "slog-span")
+	defer span.End()
+
+	sl := l.Slog().With("tenant", "acme").WithGroup("req")
+	sl.InfoContext(ctx, "grouped",
+		"method", "GET",
+		slog.Group("user", slog.String("id", "42")),
+		slog.Attr{},
+	)
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+		t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+	}
+	if record["message"] != "grouped" || record["level"] != "INFO" {
+		t.Errorf("message/level inesperados: %v", record)
+	}
+	if record["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id ausente: %v", record)
+	}
+	extra, ok := record["extra"].(map[string]any)
+	if !ok {
+		t.Fatalf("missing or invalid extra: %v", record["extra"])
+	}
+	want := map[string]string{
+		"service":     "api",
+		"tenant":      "acme",
+		"req.method":  "GET",
+		"req.user.id": "42",
+	}
+	for k, v := range want {
+		if extra[k] != v {
+			t.Errorf("extra[%q] = %v, esperado %q (extra=%v)", k, extra[k], v, extra)
+		}
+	}
+	if _, ok := extra[""]; ok {
+		t.Errorf("atributo vazio não deveria ser emitido: %v", extra)
+	}
+}
@@ -0,0 +1,215 @@
+package wslogger
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanAttributeSource fornece os atributos de um span ativo, que a API do
+// OpenTelemetry não expõe. otelsdk.SpanAttributeProcessor implementa a
+// interface para spans do SDK.
+type SpanAttributeSource interface {
+	SpanAttributes(sc trace.SpanContext) []attribute.KeyValue
+}
+
+// WithSpanAttributeSource define de onde vêm os atributos do span ativo e
+// ativa a captura (WithSpanAttributes(true)). Sem uma fonte, nenhum
+// atributo é capturado.
+func WithSpanAttributeSource(src SpanAttributeSource) Option {
+	return func(l *Logger) {
+		l.spanAttrSource = src
+		l.includeSpanAttrs = true
+	}
+}
+
+// SpanAttributeFilter seleciona e renomeia os atributos do span incluídos
+// nos registros por WithSpanAttributes.
+type SpanAttributeFilter struct {
+	Allow  []string // padrões glob (path.Match) de chaves incluídas; vazio inclui todas
+	Deny   []string // padrões glob de chaves excluídas, mesmo se permitidas
+	Prefix string   // prefixo das chaves na saída, ex.: "span."
+}
+
+// WithSpanAttributeFilter define quais atributos do span são capturados e
+// com que prefixo, e ativa a captura (WithSpanAttributes(true)).
+func WithSpanAttributeFilter(f SpanAttributeFilter) Option {
+	return func(l *Logger) {
+		l.spanAttrFilter = f
+		l.includeSpanAttrs = true
+	}
+}
+
+// capture retorna os atributos do span de sc obtidos de src, na ordem em
+// que foram definidos e com o tipo preservado (int64, float64, bool,
+// string e slices). Spans que a fonte não conhece (não gravados, no-op ou
+// já encerrados) não contribuem com campos.
+func (f SpanAttributeFilter) capture(src SpanAttributeSource, sc trace.SpanContext) []KeyValuePair {
+	if src == nil || !sc.IsValid() {
+		return nil
+	}
+	attrs := src.SpanAttributes(sc)
+	out := make([]KeyValuePair, 0, len(attrs))
+	for _, attr := range attrs {
+		key := string(attr.Key)
+		if !f.allowed(key) {
+			continue
+		}
+		out = append(out, KeyValuePair{f.Prefix + key, attr.Value.AsInterface()})
+	}
+	return out
+}
+
+func (f SpanAttributeFilter) allowed(key string) bool {
+	if len(f.Allow) > 0 && !matchAny(f.Allow, key) {
+		return false
+	}
+	return !matchAny(f.Deny, key)
+}
+
+// matchAny informa se key casa com algum dos padrões glob.
+func matchAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// spanOptions define o que é registrado no span ativo do ctx.
+type spanOptions struct {
+	events     bool
+	eventLevel Level
+	errors     bool
+	errorLevel Level
+}
+
+// WithSpanEvents registra cada log com nível >= minLevel como evento do
+// span ativo no ctx: o nome do evento é a mensagem e os atributos são o
+// nível e os extras (após hooks e redação). Spans que não estão gravando
+// são ignorados.
+func WithSpanEvents(minLevel Level) Option {
+	return func(l *Logger) {
+		l.spanOpts.events = true
+		l.spanOpts.eventLevel = minLevel
+	}
+}
+
+// WithSpanErrorStatus marca o span ativo como falho nos logs com nível >=
+// minLevel (tipicamente LevelError): o erro do registro, se houver, é
+// gravado com span.RecordError e o status vira codes.Error com a mensagem
+// como descrição.
+func WithSpanErrorStatus(minLevel Level) Option {
+	return func(l *Logger) {
+		l.spanOpts.errors = true
+		l.spanOpts.errorLevel = minLevel
+	}
+}
+
+// recordOnSpan adiciona o Record ao span do ctx conforme WithSpanEvents e
+// WithSpanErrorStatus.
+func (l *Logger) recordOnSpan(ctx context.Context, cfg config, r *Record) {
+	so := l.spanOpts
+	if ctx == nil || !(so.events && r.Level >= so.eventLevel) && !(so.errors && r.Level >= so.errorLevel) {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	ts := trace.WithTimestamp(r.Time)
+	if so.events && r.Level >= so.eventLevel {
+		attrs := make([]attribute.KeyValue, 0, len(r.fields)+1)
+		attrs = append(attrs, attribute.String("level", cfg.levels.name(r.Level)))
+		for _, kv := range r.fields {
+			attrs = append(attrs, spanEventAttrs(kv.key, kv.value, r)...)
+		}
+		span.AddEvent(r.Message, trace.WithAttributes(attrs...), ts)
+	}
+	if so.errors && r.Level >= so.errorLevel {
+		recordSpanError(span, r, ts)
+		span.SetStatus(codes.Error, r.Message)
+	}
+}
+
+// recordSpanError grava no span o primeiro erro entre os campos do Record.
+func recordSpanError(span trace.Span, r *Record, ts trace.SpanEventOption) {
+	for _, kv := range r.fields {
+		var info *errorInfo
+		switch v := kv.value.(type) {
+		case error:
+			if !isNilPointer(v) {
+				span.RecordError(v, ts)
+				return
+			}
+			// RecordError chamaria Error() num error nil tipado
+			info = newErrorInfo(v, nil)
+		case *errorInfo:
+			// erro já redigido
+			info = v
+		default:
+			continue
+		}
+		// mesmo evento que RecordError geraria
+		span.AddEvent("exception", ts, trace.WithAttributes(
+			attribute.String("exception.type", info.Type),
+			attribute.String("exception.message", info.Message),
+		))
+		return
+	}
+}
+
+// spanEventAttrs converte um extra em atributos do evento preservando os
+// tipos suportados pelo OTel (ver typedValue); erros viram key, key.type
+// e key.chain como no modo texto e os demais valores, sua forma JSON.
+func spanEventAttrs(key string, v any, r *Record) []attribute.KeyValue {
+	switch val := typedValue(v, r, false).(type) {
+	case nil:
+		return []attribute.KeyValue{attribute.String(key, "null")}
+	case string:
+		return []attribute.KeyValue{attribute.String(key, val)}
+	case bool:
+		return []attribute.KeyValue{attribute.Bool(key, val)}
+	case int64:
+		return []attribute.KeyValue{attribute.Int64(key, val)}
+	case float64:
+		return []attribute.KeyValue{attribute.Float64(key, val)}
+	case []byte:
+		return []attribute.KeyValue{attribute.String(key, base64.StdEncoding.EncodeToString(val))}
+	case []string:
+		return []attribute.KeyValue{attribute.StringSlice(key, val)}
+	case []bool:
+		return []attribute.KeyValue{attribute.BoolSlice(key, val)}
+	case []int64:
+		return []attribute.KeyValue{attribute.Int64Slice(key, val)}
+	case []float64:
+		return []attribute.KeyValue{attribute.Float64Slice(key, val)}
+	case *errorInfo:
+		attrs := []attribute.KeyValue{
+			attribute.String(key, val.Message),
+			attribute.String(key+".type", val.Type),
+		}
+		if len(val.Chain) > 0 {
+			types := make([]string, len(val.Chain))
+			for i, c := range val.Chain {
+				types[i] = c.Type
+			}
+			attrs = append(attrs, attribute.String(key+".chain", strings.Join(types, ">")))
+		}
+		return attrs
+	case json.RawMessage:
+		var s string
+		if json.Unmarshal(val, &s) != nil {
+			s = string(val)
+		}
+		return []attribute.KeyValue{attribute.String(key, s)}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thiagozs/go-wslogger/otelsdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func recordedSpan(t *testing.T, fn func(ctx context.Context)) sdktrace.ReadOnlySpan {
+	t.Helper()
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("test-logger").Start(context.Background(), "test-span")
+	fn(ctx)
+	span.End()
+	ended := sr.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("esperado 1 span, obteve %d", len(ended))
+	}
+	return ended[0]
+}
+
+func eventAttrs(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestLogger_SpanEvents(t *testing.T) {
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithClock(fixedClock),
+		WithSpanEvents(LevelInfo), WithRedaction(RedactRule{Keys: []string{"token"}}))
+	span := recordedSpan(t, func(ctx context.Context) {
+		l.DebugCtx(ctx, "abaixo do nível")
+		l.With("svc", "api").WarnCtx(ctx, "slow query", "ms", 950, "tables", []string{"orders"}, "token", "abc")
+	})
+
+	events := span.Events()
+	if len(events) != 1 {
+		t.Fatalf("esperado 1 evento, obteve %d: %+v", len(events), events)
+	}
+	ev := events[0]
+	if ev.Name != "slow query" || !ev.Time.Equal(fixedClock()) {
+		t.Errorf("evento = %q em %v", ev.Name, ev.Time)
+	}
+	attrs := eventAttrs(ev.Attributes)
+	if attrs["level"].AsString() != "WARN" || attrs["svc"].AsString() != "api" || attrs["ms"].AsInt64() != 950 {
+		t.Errorf("atributos = %v", ev.Attributes)
+	}
+	if tables := attrs["tables"].AsStringSlice(); len(tables) != 1 || tables[0] != "orders" {
+		t.Errorf("tables = %v", attrs["tables"])
+	}
+	if attrs["token"].AsString() != "***" {
+		t.Errorf("redação deveria valer para o evento: %v", attrs["token"])
+	}
+	if span.Status().Code != codes.Unset {
+		t.Errorf("status não deveria mudar sem WithSpanErrorStatus: %v", span.Status())
+	}
+}
+
+func TestLogger_SpanErrorStatus(t *testing.T) {
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithSpanErrorStatus(LevelError))
+	span := recordedSpan(t, func(ctx context.Context) {
+		l.WarnCtx(ctx, "retrying", Err(errors.New("timeout")))
+		l.ErrorCtxf(ctx, "save order: %w", fmt.Errorf("db: %w", errors.New("disk full")))
+	})
+
+	if st := span.Status(); st.Code != codes.Error || st.Description != "save order: db: disk full" {
+		t.Errorf("status = %+v", st)
+	}
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("esperado apenas o evento de RecordError, obteve %+v", events)
+	}
+	attrs := eventAttrs(events[0].Attributes)
+	if attrs["exception.message"].AsString() != "db: disk full" || attrs["exception.type"].AsString() != "*fmt.wrapError" {
+		t.Errorf("exceção = %v", events[0].Attributes)
+	}
+}
+
+func TestLogger_SpanErrorWithoutErrorField(t *testing.T) {
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithSpanEvents(LevelDebug), WithSpanErrorStatus(LevelWarn))
+	span := recordedSpan(t, func(ctx context.Context) {
+		l.WarnCtx(ctx, "quota exceeded", "user", 7)
+	})
+	if st := span.Status(); st.Code != codes.Error || st.Description != "quota exceeded" {
+		t.Errorf("status = %+v", st)
+	}
+	if events := span.Events(); len(events) != 1 || events[0].Name != "quota exceeded" {
+		t.Errorf("sem erro no registro, só o evento do log era esperado: %+v", events)
+	}
+}
+
+func TestLogger_SpanEventsWithoutRecordingSpan(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message}"),
+		WithSpanEvents(LevelDebug), WithSpanErrorStatus(LevelError))
+	l.ErrorCtx(context.Background(), "sem span")
+	l.Error("sem contexto")
+	if buf.String() != "sem span\nsem contexto\n" {
+		t.Errorf("saída = %q", buf.String())
+	}
+}
+
+func TestLogger_SpanAttributesTyped(t *testing.T) {
+	src := otelsdk.NewSpanAttributeProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(src))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "attrs")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int("http.status_code", 200),
+		attribute.Bool("cache.hit", true),
+		attribute.StringSlice("roles", []string{"admin", "ops"}),
+		attribute.String("user.email", "ana@example.com"),
+		attribute.Float64("ratio", 0.25),
+	)
+
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithJSON(true), WithSpanAttributeSource(src))
+	l.InfoCtx(ctx, "json", "foo", "bar")
+	want := `"extra":{"http.status_code":200,"cache.hit":true,"roles":["admin","ops"],` +
+		`"user.email":"ana@example.com","ratio":0.25,"foo":"bar"}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("JSON = %s", buf.String())
+	}
+
+	buf.Reset()
+	l = NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithSpanAttributeSource(src), WithSpanAttributeFilter(SpanAttributeFilter{
+		Allow:  []string{"http.*", "cache.*", "user.*"},
+		Deny:   []string{"user.email"},
+		Prefix: "span.",
+	}))
+	l.InfoCtx(ctx, "text")
+	if got := buf.String(); !strings.HasPrefix(got, "text span.http.status_code=200 span.cache.hit=true trace_id=") {
+		t.Errorf("texto = %q", got)
+	}
+
+	buf.Reset()
+	l.SetIncludeSpanAttrs(false)
+	l.InfoCtx(ctx, "off")
+	if got := strings.TrimSpace(buf.String()); !strings.HasPrefix(got, "off trace_id=") {
+		t.Errorf("captura desativada não deveria emitir atributos: %q", got)
+	}
+}
+
+func TestLogger_SpanAttributesNonRecording(t *testing.T) {
+	src := otelsdk.NewSpanAttributeProcessor()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()), sdktrace.WithSpanProcessor(src))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "dropped")
+	span.SetAttributes(attribute.String("k", "v"))
+	defer span.End()
+
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithSpanAttributeSource(src))
+	l.InfoCtx(ctx, "não amostrado", "a", 1)
+	l.InfoCtx(trace.ContextWithSpan(context.Background(), noop.Span{}), "noop")
+	if got := buf.String(); !strings.HasPrefix(got, "não amostrado a=1") || strings.Contains(got, "k=v") ||
+		!strings.Contains(got, "\nnoop\n") {
+		t.Errorf("saída = %q", got)
+	}
+}
+
+func TestLogger_SpanAttributesWithoutSource(t *testing.T) {
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "sdk")
+	span.SetAttributes(attribute.String("k", "v"))
+	defer span.End()
+
+	var buf bytes.Buffer
+	NewLogger(WithWriter(&buf), WithFormat("{message} {extra}"), WithSpanAttributes(true)).InfoCtx(ctx, "sem fonte")
+	if got := buf.String(); strings.Contains(got, "k=v") {
+		t.Errorf("sem WithSpanAttributeSource nenhum atributo deveria ser lido: %q", got)
+	}
+}
+
+func TestLogger_SpanErrorTypedNil(t *testing.T) {
+	var nilErr *ptrError
+	l := NewLogger(WithWriter(&bytes.Buffer{}), WithSpanEvents(LevelInfo), WithSpanErrorStatus(LevelError))
+	span := recordedSpan(t, func(ctx context.Context) {
+		l.ErrorCtx(ctx, "falhou", error(nilErr))
+	})
+	events := span.Events()
+	if len(events) != 2 || events[1].Name != "exception" {
+		t.Fatalf("eventos = %+v", events)
+	}
+	if attrs := eventAttrs(events[1].Attributes); attrs["exception.message"].AsString() != "<nil>" {
+		t.Errorf("exceção = %v", events[1].Attributes)
+	}
+}
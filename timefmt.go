@@ -0,0 +1,65 @@
+package wslogger
+
+import (
+	"strconv"
+	"time"
+)
+
+// Formatos de timestamp aceitos por WithTimeFormat, além de qualquer
+// layout do pacote time. Os presets Unix são emitidos como números no JSON.
+const (
+	TimeFormatDefault     = "2006-01-02 15:04:05"
+	TimeFormatRFC3339     = time.RFC3339
+	TimeFormatRFC3339Nano = time.RFC3339Nano
+	TimeFormatUnix        = "unix"      // segundos desde a época
+	TimeFormatUnixMilli   = "unixmilli" // milissegundos desde a época
+)
+
+// WithTimeFormat define o formato do timestamp em {time} e no campo "time"
+// do JSON: um layout do pacote time ou um dos presets TimeFormat*.
+func WithTimeFormat(layout string) Option {
+	return func(l *Logger) {
+		if layout != "" {
+			l.timeFormat = layout
+		}
+	}
+}
+
+// WithUTC converte os timestamps para UTC antes da formatação; por padrão
+// é usado o horário local.
+func WithUTC(enable bool) Option {
+	return func(l *Logger) { l.utc = enable }
+}
+
+// WithClock injeta a fonte de tempo do logger, útil em testes.
+func WithClock(now func() time.Time) Option {
+	return func(l *Logger) {
+		if now != nil {
+			l.clock = now
+		}
+	}
+}
+
+// now retorna o instante atual segundo o relógio configurado.
+func (l *Logger) now() time.Time {
+	if l.clock != nil {
+		return l.clock()
+	}
+	return time.Now()
+}
+
+// formatTime retorna o timestamp para o modo texto e o valor do campo
+// "time" no JSON (número para os presets Unix, string nos demais).
+func (l *Logger) formatTime(t time.Time) (string, any) {
+	if l.utc {
+		t = t.UTC()
+	}
+	switch l.timeFormat {
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10), t.Unix()
+	case TimeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10), t.UnixMilli()
+	}
+	s := t.Format(l.timeFormat)
+	return s, s
+}
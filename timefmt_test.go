@@ -0,0 +1,66 @@
+package wslogger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedClock() time.Time {
+	return time.Date(2025, 8, 18, 15, 4, 5, 123456789, time.FixedZone("BRT", -3*60*60))
+}
+
+func TestLogger_TimeFormatText(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, "2025-08-18 15:04:05"},
+		{"utc", []Option{WithUTC(true)}, "2025-08-18 18:04:05"},
+		{"rfc3339nano", []Option{WithTimeFormat(TimeFormatRFC3339Nano)}, "2025-08-18T15:04:05.123456789-03:00"},
+		{"rfc3339nano utc", []Option{WithTimeFormat(TimeFormatRFC3339Nano), WithUTC(true)}, "2025-08-18T18:04:05.123456789Z"},
+		{"unix", []Option{WithTimeFormat(TimeFormatUnix)}, "1755540245"},
+		{"unixmilli", []Option{WithTimeFormat(TimeFormatUnixMilli)}, "1755540245123"},
+		{"layout", []Option{WithTimeFormat("15:04:05.000")}, "15:04:05.123"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf strings.Builder
+			opts := append([]Option{WithWriter(&buf), WithFormat("{time}"), WithClock(fixedClock)}, tc.opts...)
+			NewLogger(opts...).Info("msg")
+			if got := strings.TrimSpace(buf.String()); got != tc.want {
+				t.Errorf("obteve %q, esperado %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogger_TimeFormatJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"default", nil, `"2025-08-18 15:04:05"`},
+		{"rfc3339nano utc", []Option{WithTimeFormat(TimeFormatRFC3339Nano), WithUTC(true)}, `"2025-08-18T18:04:05.123456789Z"`},
+		{"unix", []Option{WithTimeFormat(TimeFormatUnix)}, `1755540245`},
+		{"unixmilli", []Option{WithTimeFormat(TimeFormatUnixMilli)}, `1755540245123`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf strings.Builder
+			opts := append([]Option{WithWriter(&buf), WithJSON(true), WithClock(fixedClock)}, tc.opts...)
+			NewLogger(opts...).Info("msg")
+
+			var record map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(buf.String()), &record); err != nil {
+				t.Fatalf("failed to unmarshal log: %v\nlog line: %q", err, buf.String())
+			}
+			if got := string(record["time"]); got != tc.want {
+				t.Errorf("time = %s, esperado %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package wslogger
+
+import (
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTraceDetails inclui no JSON e no logfmt, além de trace_id e
+// span_id, os campos trace_flags, trace_sampled, trace_state (se houver),
+// parent_span_id (se conhecido), parent_remote e traceparent (W3C), por
+// exemplo para que o backend só vincule logs de traces amostrados. No
+// modo texto os mesmos valores estão sempre disponíveis como placeholders
+// ({trace_flags}, {trace_sampled}, {trace_state}, {parent_span_id},
+// {parent_remote} e {traceparent}).
+func WithTraceDetails(enable bool) Option {
+	return func(l *Logger) { l.traceDetails = enable }
+}
+
+// setTrace copia do span os IDs, as flags, o tracestate e o span pai.
+// O pai só é conhecido para spans do SDK; para um contexto remoto sem
+// span local, RemoteParent indica que o trace veio de outro processo.
+func (r *Record) setTrace(span trace.Span) {
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+	r.TraceID = sc.TraceID().String()
+	r.SpanID = sc.SpanID().String()
+	r.TraceFlags = sc.TraceFlags().String()
+	r.TraceState = sc.TraceState().String()
+	if s, ok := span.(interface{ Parent() trace.SpanContext }); ok {
+		if parent := s.Parent(); parent.IsValid() {
+			r.ParentSpanID = parent.SpanID().String()
+			r.RemoteParent = parent.IsRemote()
+		}
+		return
+	}
+	r.RemoteParent = sc.IsRemote()
+}
+
+// traceFlags decodifica TraceFlags; valores inválidos contam como zero.
+func (r *Record) traceFlags() trace.TraceFlags {
+	b, err := hex.DecodeString(r.TraceFlags)
+	if err != nil || len(b) != 1 {
+		return 0
+	}
+	return trace.TraceFlags(b[0])
+}
+
+// TraceSampled informa se o bit sampled das flags de trace está ligado.
+func (r *Record) TraceSampled() bool {
+	return r.traceFlags().IsSampled()
+}
+
+// Traceparent retorna o cabeçalho W3C traceparent do registro
+// (00-<trace_id>-<span_id>-<flags>) ou "" quando não há trace.
+func (r *Record) Traceparent() string {
+	if r.TraceID == "" || r.SpanID == "" {
+		return ""
+	}
+	return "00-" + r.TraceID + "-" + r.SpanID + "-" + r.traceFlags().String()
+}
+
+// traceDetails retorna os campos de WithTraceDetails na ordem de saída;
+// vazio quando o registro não tem trace.
+func (r *Record) traceDetails() []KeyValuePair {
+	if r.TraceID == "" {
+		return nil
+	}
+	out := []KeyValuePair{
+		{"trace_flags", r.traceFlags().String()},
+		{"trace_sampled", r.TraceSampled()},
+	}
+	if r.TraceState != "" {
+		out = append(out, KeyValuePair{"trace_state", r.TraceState})
+	}
+	if r.ParentSpanID != "" {
+		out = append(out, KeyValuePair{"parent_span_id", r.ParentSpanID})
+	}
+	out = append(out, KeyValuePair{"parent_remote", r.RemoteParent})
+	if tp := r.Traceparent(); tp != "" {
+		out = append(out, KeyValuePair{"traceparent", tp})
+	}
+	return out
+}
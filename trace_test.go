@@ -0,0 +1,111 @@
+package wslogger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// remoteChildSpan inicia um span do SDK filho de um contexto remoto
+// amostrado e com tracestate.
+func remoteChildSpan(t *testing.T) (context.Context, trace.Span, trace.SpanContext) {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	parentID, _ := trace.SpanIDFromHex("1112131415161718")
+	state, err := trace.ParseTraceState("vendor=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: parentID, TraceFlags: trace.FlagsSampled, TraceState: state, Remote: true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "child")
+	t.Cleanup(func() { span.End() })
+	return ctx, span, parent
+}
+
+func TestLogger_TracePlaceholders(t *testing.T) {
+	ctx, span, parent := remoteChildSpan(t)
+	sc := span.SpanContext()
+
+	var buf bytes.Buffer
+	l := NewLogger(WithWriter(&buf), WithFormat(
+		"{message} {trace_flags} {trace_sampled} {trace_state} {parent_span_id} {parent_remote} {traceparent}"))
+	l.InfoCtx(ctx, "child")
+	l.Info("sem trace")
+
+	want := "child trace_flags=01 trace_sampled=true trace_state=vendor=x parent_span_id=" + parent.SpanID().String() +
+		" parent_remote=true traceparent=00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01" +
+		" trace_id=" + sc.TraceID().String() + " span_id=" + sc.SpanID().String() + "\n" +
+		"sem trace\n"
+	if buf.String() != want {
+		t.Errorf("saída:\n%q\nesperado:\n%q", buf.String(), want)
+	}
+}
+
+func TestLogger_TraceDetailsJSON(t *testing.T) {
+	ctx, span, parent := remoteChildSpan(t)
+
+	var buf bytes.Buffer
+	NewLogger(WithWriter(&buf), WithJSON(true), WithTraceDetails(true)).InfoCtx(ctx, "child")
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("JSON inválido: %v", err)
+	}
+	sc := span.SpanContext()
+	wantFields := map[string]any{
+		"trace_flags":    "01",
+		"trace_sampled":  true,
+		"trace_state":    "vendor=x",
+		"parent_span_id": parent.SpanID().String(),
+		"parent_remote":  true,
+		"traceparent":    "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01",
+	}
+	for k, v := range wantFields {
+		if got[k] != v {
+			t.Errorf("%s = %v, esperado %v", k, got[k], v)
+		}
+	}
+
+	buf.Reset()
+	NewLogger(WithWriter(&buf), WithJSON(true)).InfoCtx(ctx, "sem opção")
+	if strings.Contains(buf.String(), "trace_flags") || !strings.Contains(buf.String(), `"trace_id"`) {
+		t.Errorf("detalhes só deveriam sair com WithTraceDetails: %s", buf.String())
+	}
+	buf.Reset()
+	NewLogger(WithWriter(&buf), WithJSON(true), WithTraceDetails(true)).Info("sem trace")
+	if strings.Contains(buf.String(), `"trace_flags"`) {
+		t.Errorf("sem trace nenhum campo deveria sair: %s", buf.String())
+	}
+}
+
+func TestLogger_TraceDetailsRemoteUnsampled(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, Remote: true,
+	}))
+
+	var buf bytes.Buffer
+	NewLogger(WithWriter(&buf), WithLogfmt(), WithClock(fixedClock), WithTraceDetails(true)).InfoCtx(ctx, "remote")
+	want := `msg=remote trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 trace_flags=00` +
+		` trace_sampled=false parent_remote=true traceparent=00-0102030405060708090a0b0c0d0e0f10-0102030405060708-00`
+	if got := strings.TrimSpace(buf.String()); !strings.HasSuffix(got, want) {
+		t.Errorf("logfmt = %q", got)
+	}
+}
+
+func TestLogger_ColoredTraceNotDuplicated(t *testing.T) {
+	ctx, span, _ := remoteChildSpan(t)
+	var buf bytes.Buffer
+	NewLogger(WithWriter(&buf), WithColor(true), WithFormat("{message} {trace_id} {span_id}")).InfoCtx(ctx, "hi")
+	if n := strings.Count(buf.String(), span.SpanContext().TraceID().String()); n != 1 {
+		t.Errorf("trace_id deveria aparecer uma vez, apareceu %d: %q", n, buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package wslogger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/natefinch/lumberjack"
+)
+
+// Sync descarrega o writer do logger: chama Sync (ex.: *os.File) ou Flush
+// (ex.: *bufio.Writer) quando disponíveis e fecha o arquivo corrente do
+// lumberjack, que é reaberto na próxima escrita. Writers combinados por
+// WithMultiWriter são descarregados individualmente. No modo assíncrono,
+// espera antes a fila esvaziar; é equivalente a Flush.
+func (l *Logger) Sync() error {
+	return l.Flush()
+}
+
+func syncWriter(w io.Writer) error {
+	switch v := w.(type) {
+	case *os.File:
+		// terminais e pipes não suportam fsync; não é uma falha de escrita
+		if err := v.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) {
+			return err
+		}
+		return nil
+	case *multiWriter:
+		var errs []error
+		for _, child := range v.writers {
+			errs = append(errs, syncWriter(child))
+		}
+		return errors.Join(errs...)
+	case *lumberjack.Logger:
+		return v.Close()
+	case interface{ Sync() error }:
+		return v.Sync()
+	case interface{ Flush() error }:
+		return v.Flush()
+	default:
+		return nil
+	}
+}
+
+// multiWriter replica as escritas como io.MultiWriter, mas mantém os
+// writers acessíveis para que Sync alcance cada um deles.
+type multiWriter struct {
+	writers []io.Writer
+}
+
+func newMultiWriter(writers ...io.Writer) *multiWriter {
+	return &multiWriter{writers: writers}
+}
+
+func (m *multiWriter) Write(p []byte) (int, error) {
+	for _, w := range m.writers {
+		n, err := w.Write(p)
+		if err != nil {
+			return n, err
+		}
+		if n != len(p) {
+			return n, io.ErrShortWrite
+		}
+	}
+	return len(p), nil
+}